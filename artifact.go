@@ -0,0 +1,123 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import "strings"
+
+// PackagingExtensions map the packaging and dependency types whose artifact extension differ from the type itself
+var PackagingExtensions = map[string]string{
+	"ejb":             "jar",
+	"ejb-client":      "jar",
+	"maven-plugin":    "jar",
+	"maven-archetype": "jar",
+	"bundle":          "jar",
+	"test-jar":        "jar",
+	"java-source":     "jar",
+	"javadoc":         "jar",
+}
+
+// TypeClassifiers map the dependency types implying a classifier when none is declared
+var TypeClassifiers = map[string]string{
+	"test-jar":    "tests",
+	"ejb-client":  "client",
+	"java-source": "sources",
+	"javadoc":     "javadoc",
+}
+
+// ArtifactExtension return the file extension of the artifact produced by the project packaging
+func (mp *MavenProject) ArtifactExtension() string {
+	packaging := mp.Interpolate(mp.Packaging)
+	if packaging == "" {
+		packaging = "jar"
+	}
+	if extension, exist := PackagingExtensions[packaging]; exist {
+		return extension
+	}
+	return packaging
+}
+
+// Represent maven coordinates designating an artifact. Depending on the context the version may be
+// empty or hold a version range.
+type Artifact struct {
+	GroupId    string
+	ArtifactId string
+	Version    string
+}
+
+// String return the groupId:artifactId[:version] form of the artifact
+func (a Artifact) String() string {
+	if a.Version == "" {
+		return a.GroupId + ":" + a.ArtifactId
+	}
+	return a.GroupId + ":" + a.ArtifactId + ":" + a.Version
+}
+
+// matchesVersion return true if version is matched by the version specification of the artifact:
+// any version when it has none, otherwise a range such as (,3.0) or an exact version. An unknown
+// version only matches an artifact without version.
+func (a Artifact) matchesVersion(version string) bool {
+	if a.Version == "" {
+		return true
+	}
+	vr, err := ParseVersionRange(a.Version)
+	return err == nil && version != "" && vr.Contains(version)
+}
+
+// EffectiveExtension return the file extension of the dependency artifact, derived from its type
+func (d Dependency) EffectiveExtension() string {
+	if extension, exist := PackagingExtensions[d.EffectiveType()]; exist {
+		return extension
+	}
+	return d.EffectiveType()
+}
+
+// LocalRepoPath return the path of the dependency artifact relative to the root of a maven
+// repository (e.g. org/slf4j/slf4j-api/1.7.30/slf4j-api-1.7.30.jar). The dependency is expected
+// to be resolved, see MavenProject.ResolvedDependencies.
+func (d Dependency) LocalRepoPath() string {
+	file := d.ArtifactId + "-" + d.Version
+	if classifier := d.EffectiveClassifier(); classifier != "" {
+		file += "-" + classifier
+	}
+	file += "." + d.EffectiveExtension()
+	return strings.Replace(d.GroupId, ".", "/", -1) + "/" + d.ArtifactId + "/" + d.Version + "/" + file
+}
+
+// RemoteURL return the url of the dependency artifact in the remote repository located at repositoryUrl
+func (d Dependency) RemoteURL(repositoryUrl string) string {
+	return strings.TrimRight(repositoryUrl, "/") + "/" + d.LocalRepoPath()
+}
+
+// FinalArtifactName return the file name of the artifact produced by the project: the build
+// finalName, defaulting to artifactId-version, followed by the classifier implied by the packaging
+// (see TypeClassifiers) and the extension returned by ArtifactExtension
+func (mp *MavenProject) FinalArtifactName() string {
+	name := mp.Interpolate(mp.Build.FinalName)
+	if name == "" {
+		name = mp.Interpolate(mp.ArtifactId) + "-" + mp.Interpolate(mp.EffectiveVersion())
+	}
+	if classifier, exist := TypeClassifiers[mp.Interpolate(mp.Packaging)]; exist {
+		name += "-" + classifier
+	}
+	return name + "." + mp.ArtifactExtension()
+}
@@ -0,0 +1,111 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestMavenProject_ArtifactExtension(t *testing.T) {
+	tests := map[string]string{
+		"":             "jar",
+		"jar":          "jar",
+		"war":          "war",
+		"maven-plugin": "jar",
+		"pom":          "pom",
+	}
+
+	for packaging, expected := range tests {
+		project := MavenProject{Packaging: packaging}
+		if extension := project.ArtifactExtension(); extension != expected {
+			t.Errorf("extension for %s does not match (expected: %s, found: %s)", packaging, expected, extension)
+		}
+	}
+}
+
+func TestDependency_LocalRepoPath(t *testing.T) {
+	tests := []struct {
+		dep      Dependency
+		expected string
+	}{
+		{
+			dep:      Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+			expected: "org/slf4j/slf4j-api/1.7.30/slf4j-api-1.7.30.jar",
+		},
+		{
+			dep:      Dependency{GroupId: "org.example.ejb", ArtifactId: "orders", Version: "2.1", Type: "ejb-client"},
+			expected: "org/example/ejb/orders/2.1/orders-2.1-client.jar",
+		},
+		{
+			dep:      Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30", Type: "javadoc"},
+			expected: "org/slf4j/slf4j-api/1.7.30/slf4j-api-1.7.30-javadoc.jar",
+		},
+		{
+			dep:      Dependency{GroupId: "org.example", ArtifactId: "parent", Version: "1.0", Type: "pom"},
+			expected: "org/example/parent/1.0/parent-1.0.pom",
+		},
+	}
+
+	for _, test := range tests {
+		if path := test.dep.LocalRepoPath(); path != test.expected {
+			t.Errorf("path does not match (expected: %s, found: %s)", test.expected, path)
+		}
+	}
+
+	dep := Dependency{GroupId: "org.example", ArtifactId: "core", Version: "1.0", Type: "test-jar"}
+	expected := "https://repo.maven.apache.org/maven2/org/example/core/1.0/core-1.0-tests.jar"
+	if url := dep.RemoteURL("https://repo.maven.apache.org/maven2/"); url != expected {
+		t.Errorf("url does not match (expected: %s, found: %s)", expected, url)
+	}
+}
+
+func TestMavenProject_FinalArtifactName(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>my-maven-plugin</artifactId>
+    <version>1.0.0</version>
+    <packaging>maven-plugin</packaging>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if name := project.FinalArtifactName(); name != "my-maven-plugin-1.0.0.jar" {
+		t.Errorf("name does not match (expected: my-maven-plugin-1.0.0.jar, found: %s)", name)
+	}
+
+	project.Packaging = "test-jar"
+	if name := project.FinalArtifactName(); name != "my-maven-plugin-1.0.0-tests.jar" {
+		t.Errorf("name does not match (expected: my-maven-plugin-1.0.0-tests.jar, found: %s)", name)
+	}
+
+	project.Packaging = "war"
+	project.Build.FinalName = "${project.artifactId}"
+	if name := project.FinalArtifactName(); name != "my-maven-plugin.war" {
+		t.Errorf("name does not match (expected: my-maven-plugin.war, found: %s)", name)
+	}
+}
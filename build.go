@@ -0,0 +1,294 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSourceDirectory is the source directory of a project not declaring one
+const DefaultSourceDirectory = "src/main/java"
+
+// EffectiveSourceDirectory return the source directory of the project once the profiles active in
+// given context are applied and the properties resolved, relative to the project base directory
+// (a ${basedir} or ${project.basedir} prefix is dropped). It defaults to DefaultSourceDirectory.
+func (mp *MavenProject) EffectiveSourceDirectory(ctx ActivationContext) string {
+	applied := mp.ApplyProfiles(ctx)
+	dir := applied.InterpolateContext(strings.TrimSpace(applied.Build.SourceDirectory), ctx)
+	if dir == "" {
+		return DefaultSourceDirectory
+	}
+	for _, prefix := range []string{"${basedir}", "${project.basedir}"} {
+		if strings.HasPrefix(dir, prefix) {
+			dir = strings.TrimPrefix(strings.TrimPrefix(dir, prefix), "/")
+			break
+		}
+	}
+	if dir == "" {
+		return "."
+	}
+	return path.Clean(dir)
+}
+
+// FilteredResources return the resources and test resources that undergo property substitution
+func (mp *MavenProject) FilteredResources() []Resource {
+	var resources []Resource
+	for _, resource := range mp.Build.Resources {
+		if resource.Filtering {
+			resources = append(resources, resource)
+		}
+	}
+	for _, resource := range mp.Build.TestResources {
+		if resource.Filtering {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// EffectiveGroupId return the groupId of the plugin, defaulting to org.apache.maven.plugins
+func (p Plugin) EffectiveGroupId() string {
+	if p.GroupId == "" {
+		return "org.apache.maven.plugins"
+	}
+	return p.GroupId
+}
+
+// key return the groupId:artifactId identifying the plugin
+func (p Plugin) key() string {
+	return p.EffectiveGroupId() + ":" + p.ArtifactId
+}
+
+// ApplyPluginManagement return a copy of plugin with the version it does not declare
+// filled from the matching pluginManagement entry
+func (mp *MavenProject) ApplyPluginManagement(plugin Plugin) Plugin {
+	for _, managed := range mp.Build.PluginManagement.Plugins {
+		if managed.key() == plugin.key() {
+			if plugin.Version == "" {
+				plugin.Version = managed.Version
+			}
+			break
+		}
+	}
+	return plugin
+}
+
+// ResolvedPlugins return the build plugins of the project with pluginManagement applied
+// and properties interpolated
+func (mp *MavenProject) ResolvedPlugins() []Plugin {
+	var plugins []Plugin
+	for _, plugin := range mp.Build.Plugins {
+		plugin = mp.ApplyPluginManagement(plugin)
+		plugin.GroupId = mp.Interpolate(plugin.GroupId)
+		plugin.ArtifactId = mp.Interpolate(plugin.ArtifactId)
+		plugin.Version = mp.Interpolate(plugin.Version)
+		plugins = append(plugins, plugin)
+	}
+	return plugins
+}
+
+// UnpinnedPlugins return the build plugins without an explicit version once pluginManagement is applied
+func (mp *MavenProject) UnpinnedPlugins() []Plugin {
+	var plugins []Plugin
+	for _, plugin := range mp.ResolvedPlugins() {
+		if plugin.Version == "" {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// PluginsNotManaged return the build plugins declared by the project whose version is not supplied
+// by the local or inherited pluginManagement (the parent chain is located using resolver). When the
+// parent chain can't be resolved only the local pluginManagement is considered.
+func (mp *MavenProject) PluginsNotManaged(resolver ParentResolver) []Plugin {
+	effective, err := mp.EffectivePOM(resolver)
+	if err != nil {
+		effective = mp
+	}
+
+	var plugins []Plugin
+	for _, plugin := range mp.Build.Plugins {
+		managed := false
+		for _, candidate := range effective.Build.PluginManagement.Plugins {
+			if candidate.key() == plugin.key() && candidate.Version != "" {
+				managed = true
+				break
+			}
+		}
+		if !managed {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// IsReproducibleConfigured return true if the project.build.outputTimestamp property, required by
+// reproducible builds, is set and resolvable
+func (mp *MavenProject) IsReproducibleConfigured() bool {
+	value, exist := mp.Properties["project.build.outputTimestamp"]
+	if !exist {
+		return false
+	}
+	value = strings.TrimSpace(mp.Interpolate(value))
+	return value != "" && !strings.Contains(value, "${")
+}
+
+// OutputTimestamp return the resolved project.build.outputTimestamp property of reproducible builds,
+// either an ISO-8601 date (e.g. 2021-05-01T10:00:00Z) or a number of seconds since the Unix epoch.
+// It is not found if the property is unset, unresolved (e.g. ${git.commit.time}) or malformed.
+func (mp *MavenProject) OutputTimestamp() (time.Time, bool) {
+	value, exist := mp.lookupProperty("project.build.outputTimestamp")
+	if !exist {
+		return time.Time{}, false
+	}
+	value = strings.TrimSpace(mp.Interpolate(value))
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), true
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, true
+	}
+	return time.Time{}, false
+}
+
+// BannedPlugins return the build plugins matching one of the banned artifacts, once pluginManagement
+// is applied. A banned artifact without version matches every version of the plugin, otherwise its
+// version is a maven version specification: a range such as (,3.0) or an exact version. A plugin
+// whose version is unknown only matches a ban without version.
+func (mp *MavenProject) BannedPlugins(banned []Artifact) []Plugin {
+	var plugins []Plugin
+	for _, plugin := range mp.ResolvedPlugins() {
+		for _, ban := range banned {
+			groupId := ban.GroupId
+			if groupId == "" {
+				groupId = "org.apache.maven.plugins"
+			}
+			if groupId != plugin.EffectiveGroupId() || ban.ArtifactId != plugin.ArtifactId || !ban.matchesVersion(plugin.Version) {
+				continue
+			}
+			plugins = append(plugins, plugin)
+			break
+		}
+	}
+	return plugins
+}
+
+// LifecyclePhases list the phases of the clean, default and site lifecycles in execution order
+var LifecyclePhases = []string{
+	"pre-clean", "clean", "post-clean",
+	"validate", "initialize", "generate-sources", "process-sources", "generate-resources",
+	"process-resources", "compile", "process-classes", "generate-test-sources", "process-test-sources",
+	"generate-test-resources", "process-test-resources", "test-compile", "process-test-classes", "test",
+	"prepare-package", "package", "pre-integration-test", "integration-test", "post-integration-test",
+	"verify", "install", "deploy",
+	"pre-site", "site", "post-site", "site-deploy",
+}
+
+// BoundPhases return the distinct phases the build plugin executions are explicitly bound to, in
+// lifecycle order. Unknown phases come last, in declaration order. Executions relying on the
+// default phase of their goals are not considered.
+func (mp *MavenProject) BoundPhases() []string {
+	bound := map[string]bool{}
+	var unknown []string
+	for _, plugin := range mp.ResolvedPlugins() {
+		for _, execution := range plugin.Executions {
+			phase := strings.TrimSpace(mp.Interpolate(execution.Phase))
+			if phase == "" || bound[phase] {
+				continue
+			}
+			bound[phase] = true
+			if !contains(LifecyclePhases, phase) {
+				unknown = append(unknown, phase)
+			}
+		}
+	}
+
+	var phases []string
+	for _, phase := range LifecyclePhases {
+		if bound[phase] {
+			phases = append(phases, phase)
+		}
+	}
+	return append(phases, unknown...)
+}
+
+// lifecycleStarts list the first phase of each lifecycle of LifecyclePhases
+var lifecycleStarts = []string{"pre-clean", "validate", "pre-site"}
+
+// Represent a plugin goal bound to a phase by an execution
+type PluginGoal struct {
+	GroupId     string
+	ArtifactId  string
+	Version     string
+	ExecutionId string
+	Phase       string
+	Goal        string
+}
+
+// GoalsUpTo return the plugin goals run when building up to given phase (e.g. mvn package): the goals
+// of the executions bound to a phase of the same lifecycle at or before it, in lifecycle order then
+// declaration order. As for BoundPhases, executions without explicit phase are not considered.
+func (mp *MavenProject) GoalsUpTo(phase string) []PluginGoal {
+	target := -1
+	for i, p := range LifecyclePhases {
+		if p == phase {
+			target = i
+			break
+		}
+	}
+	if target == -1 {
+		return nil
+	}
+	start := target
+	for !contains(lifecycleStarts, LifecyclePhases[start]) {
+		start--
+	}
+
+	plugins := mp.ResolvedPlugins()
+	var goals []PluginGoal
+	for _, p := range LifecyclePhases[start : target+1] {
+		for _, plugin := range plugins {
+			for _, execution := range plugin.Executions {
+				if strings.TrimSpace(mp.Interpolate(execution.Phase)) != p {
+					continue
+				}
+				for _, goal := range execution.Goals {
+					goals = append(goals, PluginGoal{
+						GroupId:     plugin.EffectiveGroupId(),
+						ArtifactId:  plugin.ArtifactId,
+						Version:     plugin.Version,
+						ExecutionId: execution.Id,
+						Phase:       p,
+						Goal:        strings.TrimSpace(goal),
+					})
+				}
+			}
+		}
+	}
+	return goals
+}
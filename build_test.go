@@ -0,0 +1,453 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMavenProject_FilteredResources(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <resources>
+            <resource>
+                <directory>src/main/resources</directory>
+            </resource>
+        </resources>
+        <testResources>
+            <testResource>
+                <directory>src/test/resources</directory>
+                <targetPath>test-classes/config</targetPath>
+                <filtering>true</filtering>
+                <includes>
+                    <include>**/*.properties</include>
+                </includes>
+                <excludes>
+                    <exclude>**/*.bin</exclude>
+                </excludes>
+            </testResource>
+        </testResources>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if len(project.Build.Resources) != 1 {
+		t.Errorf("expecting 1 resource found %d", len(project.Build.Resources))
+	}
+
+	resources := project.FilteredResources()
+	if len(resources) != 1 {
+		t.Fatalf("expecting 1 filtered resource found %d", len(resources))
+	}
+	resource := resources[0]
+	if resource.Directory != "src/test/resources" {
+		t.Errorf("directory does not match (expected: src/test/resources, found: %s)", resource.Directory)
+	}
+	if resource.TargetPath != "test-classes/config" {
+		t.Errorf("targetPath does not match (expected: test-classes/config, found: %s)", resource.TargetPath)
+	}
+	if len(resource.Includes) != 1 || resource.Includes[0] != "**/*.properties" {
+		t.Errorf("includes does not match (expected: [**/*.properties], found: %v)", resource.Includes)
+	}
+	if len(resource.Excludes) != 1 || resource.Excludes[0] != "**/*.bin" {
+		t.Errorf("excludes does not match (expected: [**/*.bin], found: %v)", resource.Excludes)
+	}
+}
+
+func TestMavenProject_EffectiveSourceDirectory(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <src.dir>${project.basedir}/src/java</src.dir>
+    </properties>
+    <build>
+        <sourceDirectory>${src.dir}</sourceDirectory>
+    </build>
+    <profiles>
+        <profile>
+            <id>generated</id>
+            <build>
+                <sourceDirectory>${basedir}/target/generated-sources</sourceDirectory>
+            </build>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if dir := project.EffectiveSourceDirectory(ActivationContext{}); dir != "src/java" {
+		t.Errorf("source directory does not match (expected: src/java, found: %s)", dir)
+	}
+	ctx := ActivationContext{ActiveProfiles: []string{"generated"}}
+	if dir := project.EffectiveSourceDirectory(ctx); dir != "target/generated-sources" {
+		t.Errorf("source directory does not match (expected: target/generated-sources, found: %s)", dir)
+	}
+	if dir := (&MavenProject{}).EffectiveSourceDirectory(ActivationContext{}); dir != DefaultSourceDirectory {
+		t.Errorf("source directory does not match (expected: %s, found: %s)", DefaultSourceDirectory, dir)
+	}
+}
+
+func TestMavenProject_UnpinnedPlugins(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.0</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <artifactId>maven-war-plugin</artifactId>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	plugins := project.UnpinnedPlugins()
+	if len(plugins) != 1 {
+		t.Fatalf("expecting 1 unpinned plugin found %d", len(plugins))
+	}
+	if plugins[0].ArtifactId != "maven-war-plugin" {
+		t.Errorf("artifactId does not match (expected: maven-war-plugin, found: %s)", plugins[0].ArtifactId)
+	}
+}
+
+func TestMavenProject_PluginsNotManaged(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:parent:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.1</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+    </build>
+</project>`,
+	}
+
+	pomStr := `
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-jar-plugin</artifactId>
+                    <version>3.2.0</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <artifactId>maven-jar-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <artifactId>maven-war-plugin</artifactId>
+                <version>3.3.1</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	plugins := project.PluginsNotManaged(resolver)
+	if len(plugins) != 1 || plugins[0].ArtifactId != "maven-war-plugin" {
+		t.Errorf("unmanaged plugins does not match (expected: [maven-war-plugin], found: %v)", plugins)
+	}
+
+	// without the parent chain, only the local pluginManagement is considered
+	plugins = project.PluginsNotManaged(mapResolver{})
+	if len(plugins) != 2 || plugins[0].ArtifactId != "maven-compiler-plugin" {
+		t.Errorf("unmanaged plugins does not match (expected: [maven-compiler-plugin maven-war-plugin], found: %v)", plugins)
+	}
+}
+
+func TestBuild_DefaultGoal(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <defaultGoal>clean install</defaultGoal>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if project.Build.DefaultGoal != "clean install" {
+		t.Errorf("defaultGoal does not match (expected: clean install, found: %s)", project.Build.DefaultGoal)
+	}
+}
+
+func TestMavenProject_IsReproducibleConfigured(t *testing.T) {
+	project := MavenProject{Properties: Properties{"project.build.outputTimestamp": "2020-01-01T00:00:00Z"}}
+	if !project.IsReproducibleConfigured() {
+		t.Error("expecting project to be reproducible configured")
+	}
+
+	project = MavenProject{Properties: Properties{"project.build.sourceEncoding": "UTF-8"}}
+	if project.IsReproducibleConfigured() {
+		t.Error("expecting project not to be reproducible configured")
+	}
+
+	project = MavenProject{Properties: Properties{"project.build.outputTimestamp": "${git.commit.time}"}}
+	if project.IsReproducibleConfigured() {
+		t.Error("expecting project with unresolved timestamp not to be reproducible configured")
+	}
+}
+
+func TestMavenProject_OutputTimestamp(t *testing.T) {
+	tests := map[string]time.Time{
+		"2021-05-01T10:00:00Z":      time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2021-05-01T12:00:00+02:00": time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+		"${build.time}":             time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+		"1619863200":                time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	for value, expected := range tests {
+		project := MavenProject{Properties: Properties{
+			"project.build.outputTimestamp": value,
+			"build.time":                    "2021-05-01T10:00:00Z",
+		}}
+		timestamp, found := project.OutputTimestamp()
+		if !found || !timestamp.Equal(expected) {
+			t.Errorf("timestamp of %s does not match (expected: %s, found: %s)", value, expected, timestamp)
+		}
+	}
+
+	for _, value := range []string{"${git.commit.time}", "yesterday"} {
+		project := MavenProject{Properties: Properties{"project.build.outputTimestamp": value}}
+		if _, found := project.OutputTimestamp(); found {
+			t.Errorf("expecting no timestamp for %s", value)
+		}
+	}
+	if _, found := (&MavenProject{}).OutputTimestamp(); found {
+		t.Error("expecting no timestamp when the property is unset")
+	}
+}
+
+func TestMavenProject_BannedPlugins(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-jar-plugin</artifactId>
+                <version>2.4</version>
+            </plugin>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.8.1</version>
+            </plugin>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>exec-maven-plugin</artifactId>
+                <version>1.6.0</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	banned := project.BannedPlugins([]Artifact{
+		{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-jar-plugin", Version: "(,3.0)"},
+		{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-compiler-plugin", Version: "(,3.0)"},
+		{GroupId: "org.codehaus.mojo", ArtifactId: "exec-maven-plugin"},
+	})
+	if len(banned) != 2 {
+		t.Fatalf("expecting 2 banned plugins found %d", len(banned))
+	}
+	if banned[0].ArtifactId != "maven-jar-plugin" {
+		t.Errorf("banned plugin does not match (expected: maven-jar-plugin, found: %s)", banned[0].ArtifactId)
+	}
+	if banned[1].ArtifactId != "exec-maven-plugin" {
+		t.Errorf("banned plugin does not match (expected: exec-maven-plugin, found: %s)", banned[1].ArtifactId)
+	}
+}
+
+func TestMavenProject_BoundPhases(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-failsafe-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <phase>integration-test</phase>
+                        <goals><goal>integration-test</goal></goals>
+                    </execution>
+                    <execution>
+                        <phase>verify</phase>
+                        <goals><goal>verify</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>build-helper-maven-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <phase>generate-sources</phase>
+                        <goals><goal>add-source</goal></goals>
+                    </execution>
+                    <execution>
+                        <goals><goal>timestamp-property</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <artifactId>maven-source-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <phase>verify</phase>
+                        <goals><goal>jar-no-fork</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	phases := project.BoundPhases()
+	expected := []string{"generate-sources", "integration-test", "verify"}
+	if !reflect.DeepEqual(phases, expected) {
+		t.Errorf("phases do not match (expected: %v, found: %v)", expected, phases)
+	}
+}
+
+func TestMavenProject_GoalsUpTo(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-source-plugin</artifactId>
+                <version>3.2.1</version>
+                <executions>
+                    <execution>
+                        <id>attach-sources</id>
+                        <phase>package</phase>
+                        <goals><goal>jar-no-fork</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>build-helper-maven-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <id>sources</id>
+                        <phase>compile</phase>
+                        <goals><goal>add-source</goal></goals>
+                    </execution>
+                    <execution>
+                        <id>notify</id>
+                        <phase>deploy</phase>
+                        <goals><goal>attach-artifact</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <artifactId>maven-clean-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <id>wipe</id>
+                        <phase>clean</phase>
+                        <goals><goal>clean</goal></goals>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	expected := []PluginGoal{
+		{GroupId: "org.codehaus.mojo", ArtifactId: "build-helper-maven-plugin", ExecutionId: "sources", Phase: "compile", Goal: "add-source"},
+		{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-source-plugin", Version: "3.2.1", ExecutionId: "attach-sources",
+			Phase: "package", Goal: "jar-no-fork"},
+	}
+	if goals := project.GoalsUpTo("package"); !reflect.DeepEqual(goals, expected) {
+		t.Errorf("goals do not match (expected: %v, found: %v)", expected, goals)
+	}
+	if goals := project.GoalsUpTo("unknown"); len(goals) != 0 {
+		t.Errorf("expecting no goal for an unknown phase, found %v", goals)
+	}
+}
@@ -0,0 +1,296 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Represent a free-form configuration element, such as the <configuration> of a plugin
+type Config struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Value    string     `xml:",chardata"`
+	Children []Config   `xml:",any"`
+}
+
+// Child return the first child element with given name
+func (c Config) Child(name string) (Config, bool) {
+	for _, child := range c.Children {
+		if child.XMLName.Local == name {
+			return child, true
+		}
+	}
+	return Config{}, false
+}
+
+// Get return the element at given dotted path (e.g. archive.manifest.mainClass)
+func (c Config) Get(path string) (Config, bool) {
+	current := c
+	for _, name := range strings.Split(path, ".") {
+		child, exist := current.Child(name)
+		if !exist {
+			return Config{}, false
+		}
+		current = child
+	}
+	return current, true
+}
+
+// Lookup return the trimmed text of the element at given dotted path
+func (c Config) Lookup(path string) (string, bool) {
+	element, exist := c.Get(path)
+	if !exist {
+		return "", false
+	}
+	return strings.TrimSpace(element.Value), true
+}
+
+// Values return the trimmed text of the children of the element at given dotted path,
+// typically the items of a list such as <includes><include>...</include></includes>
+func (c Config) Values(path string) []string {
+	element, exist := c.Get(path)
+	if !exist {
+		return nil
+	}
+
+	var values []string
+	for _, child := range element.Children {
+		values = append(values, strings.TrimSpace(child.Value))
+	}
+	return values
+}
+
+// SkippedPlugins return the build plugins whose configuration contains <skip>true</skip>
+func (mp *MavenProject) SkippedPlugins() []Plugin {
+	var plugins []Plugin
+	for _, plugin := range mp.ResolvedPlugins() {
+		if skip, exist := plugin.Configuration.Lookup("skip"); exist && strings.EqualFold(mp.Interpolate(skip), "true") {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// MainClass return the main class declared by the maven-jar-plugin manifest, a maven-shade-plugin
+// ManifestResourceTransformer or the maven-assembly-plugin manifest, in that order. The configuration
+// of the plugin executions is considered after the plugin-level one.
+func (mp *MavenProject) MainClass() (string, bool) {
+	plugins := map[string]Plugin{}
+	for _, plugin := range mp.ResolvedPlugins() {
+		plugins[plugin.key()] = plugin
+	}
+
+	if plugin, exist := plugins["org.apache.maven.plugins:maven-jar-plugin"]; exist {
+		for _, config := range plugin.configurations() {
+			if mainClass, exist := config.Lookup("archive.manifest.mainClass"); exist && mainClass != "" {
+				return mp.Interpolate(mainClass), true
+			}
+		}
+	}
+
+	if plugin, exist := plugins["org.apache.maven.plugins:maven-shade-plugin"]; exist {
+		for _, config := range plugin.configurations() {
+			transformers, exist := config.Get("transformers")
+			if !exist {
+				continue
+			}
+			for _, transformer := range transformers.Children {
+				mainClass, exist := transformer.Lookup("mainClass")
+				if exist && mainClass != "" && strings.HasSuffix(transformer.attr("implementation"), "ManifestResourceTransformer") {
+					return mp.Interpolate(mainClass), true
+				}
+			}
+		}
+	}
+
+	if plugin, exist := plugins["org.apache.maven.plugins:maven-assembly-plugin"]; exist {
+		for _, config := range plugin.configurations() {
+			if mainClass, exist := config.Lookup("archive.manifest.mainClass"); exist && mainClass != "" {
+				return mp.Interpolate(mainClass), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// configurations return the plugin-level configuration followed by the configuration of each execution
+func (p Plugin) configurations() []Config {
+	configs := []Config{p.Configuration}
+	for _, execution := range p.Executions {
+		configs = append(configs, execution.Configuration)
+	}
+	return configs
+}
+
+// ExecutionConfig return the configuration of the execution with given id of the build plugin
+// designated by groupId:artifactId (the groupId defaults to org.apache.maven.plugins when omitted).
+// As in maven, the execution configuration inherits the plugin-level elements it does not declare.
+func (mp *MavenProject) ExecutionConfig(pluginGA, executionId string) (Config, bool) {
+	if !strings.Contains(pluginGA, ":") {
+		pluginGA = "org.apache.maven.plugins:" + pluginGA
+	}
+	plugin, exist := mp.plugin(pluginGA)
+	if !exist {
+		return Config{}, false
+	}
+
+	for _, execution := range plugin.Executions {
+		// executions without id are named default by maven
+		if id := execution.Id; id != executionId && (id != "" || executionId != "default") {
+			continue
+		}
+		config := execution.Configuration
+		config.Children = append([]Config{}, execution.Configuration.Children...)
+		for _, child := range plugin.Configuration.Children {
+			if _, declared := execution.Configuration.Child(child.XMLName.Local); !declared {
+				config.Children = append(config.Children, child)
+			}
+		}
+		return config, true
+	}
+	return Config{}, false
+}
+
+// attr return the value of the attribute with given name, or an empty string
+func (c Config) attr(name string) string {
+	for _, attr := range c.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// ConfigKeys return the dotted path of every leaf element of the plugin configuration
+// (e.g. archive.manifest.mainClass), in declaration order and without duplicates
+func (p *Plugin) ConfigKeys() []string {
+	var keys []string
+	seen := map[string]bool{}
+
+	var walk func(c Config, prefix string)
+	walk = func(c Config, prefix string) {
+		for _, child := range c.Children {
+			path := child.XMLName.Local
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			if len(child.Children) > 0 {
+				walk(child, path)
+			} else if !seen[path] {
+				seen[path] = true
+				keys = append(keys, path)
+			}
+		}
+	}
+
+	walk(p.Configuration, "")
+	return keys
+}
+
+// TestIncludes return the test patterns listed by the <includes> and <excludes> configuration of
+// the maven-surefire-plugin and maven-failsafe-plugin, selecting the tests actually run
+func (mp *MavenProject) TestIncludes() (includes, excludes []string) {
+	for _, plugin := range mp.ResolvedPlugins() {
+		if plugin.key() != "org.apache.maven.plugins:maven-surefire-plugin" &&
+			plugin.key() != "org.apache.maven.plugins:maven-failsafe-plugin" {
+			continue
+		}
+		for _, include := range plugin.Configuration.Values("includes") {
+			includes = append(includes, mp.Interpolate(include))
+		}
+		for _, exclude := range plugin.Configuration.Values("excludes") {
+			excludes = append(excludes, mp.Interpolate(exclude))
+		}
+	}
+	return includes, excludes
+}
+
+// plugin return the resolved build plugin with given groupId:artifactId
+func (mp *MavenProject) plugin(key string) (Plugin, bool) {
+	for _, plugin := range mp.ResolvedPlugins() {
+		if plugin.key() == key {
+			return plugin, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// pluginSetting return the interpolated value of the plugin configuration element, falling back
+// to the project property the plugin reads by default (e.g. maven.compiler.source)
+func (mp *MavenProject) pluginSetting(plugin Plugin, path, property string) string {
+	if value, exist := plugin.Configuration.Lookup(path); exist {
+		return mp.Interpolate(value)
+	}
+	if property == "" {
+		return ""
+	}
+	value, _ := mp.lookupProperty(property)
+	return mp.Interpolate(value)
+}
+
+// CompilerConfig return the source, target and release levels and the encoding configured for the
+// maven-compiler-plugin, or set through the maven.compiler.* and project.build.sourceEncoding properties
+func (mp *MavenProject) CompilerConfig() (source, target, release, encoding string) {
+	plugin, _ := mp.plugin("org.apache.maven.plugins:maven-compiler-plugin")
+	return mp.pluginSetting(plugin, "source", "maven.compiler.source"),
+		mp.pluginSetting(plugin, "target", "maven.compiler.target"),
+		mp.pluginSetting(plugin, "release", "maven.compiler.release"),
+		mp.pluginSetting(plugin, "encoding", "project.build.sourceEncoding")
+}
+
+// SurefireConfig return whether the tests are skipped (skip or skipTests, configured or set through
+// the maven.test.skip and skipTests properties), the argLine and the includes of the maven-surefire-plugin
+func (mp *MavenProject) SurefireConfig() (skip bool, argLine string, includes []string) {
+	plugin, _ := mp.plugin("org.apache.maven.plugins:maven-surefire-plugin")
+
+	skip = strings.EqualFold(mp.pluginSetting(plugin, "skip", "maven.test.skip"), "true") ||
+		strings.EqualFold(mp.pluginSetting(plugin, "skipTests", "skipTests"), "true")
+	argLine = mp.pluginSetting(plugin, "argLine", "argLine")
+	for _, include := range plugin.Configuration.Values("includes") {
+		includes = append(includes, mp.Interpolate(include))
+	}
+	return skip, argLine, includes
+}
+
+// RequiredJavaRelease return the highest java release declared by the compiler release, source
+// or target (see CompilerConfig), the legacy 1.x form being normalized (1.8 is 8)
+func (mp *MavenProject) RequiredJavaRelease() (int, bool) {
+	source, target, release, _ := mp.CompilerConfig()
+
+	required, found := 0, false
+	for _, level := range []string{release, source, target} {
+		level = strings.TrimPrefix(strings.TrimSpace(level), "1.")
+		n, err := strconv.Atoi(level)
+		if err != nil {
+			continue
+		}
+		if !found || n > required {
+			required, found = n, true
+		}
+	}
+	return required, found
+}
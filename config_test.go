@@ -0,0 +1,448 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Lookup(t *testing.T) {
+	configStr := `
+<configuration>
+    <archive>
+        <manifest>
+            <mainClass> com.example.Main </mainClass>
+        </manifest>
+    </archive>
+    <includes>
+        <include>**/*Test.java</include>
+        <include>**/*IT.java</include>
+    </includes>
+</configuration>`
+
+	var config Config
+	if err := xml.Unmarshal([]byte(configStr), &config); err != nil {
+		t.Fatalf("unable to unmarshal configuration. Reason: %s", err)
+	}
+
+	if value, exist := config.Lookup("archive.manifest.mainClass"); !exist || value != "com.example.Main" {
+		t.Errorf("mainClass does not match (expected: com.example.Main, found: %s)", value)
+	}
+	if _, exist := config.Lookup("archive.manifest.unknown"); exist {
+		t.Error("expecting unknown element not to exist")
+	}
+	if values := config.Values("includes"); len(values) != 2 || values[1] != "**/*IT.java" {
+		t.Errorf("includes does not match (expected: [**/*Test.java **/*IT.java], found: %v)", values)
+	}
+}
+
+func TestMavenProject_SkippedPlugins(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <skipITs>true</skipITs>
+    </properties>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <version>2.22.2</version>
+                <configuration>
+                    <skip>true</skip>
+                </configuration>
+            </plugin>
+            <plugin>
+                <artifactId>maven-failsafe-plugin</artifactId>
+                <version>2.22.2</version>
+                <configuration>
+                    <skip>${skipITs}</skip>
+                </configuration>
+            </plugin>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.8.0</version>
+                <configuration>
+                    <release>11</release>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	plugins := project.SkippedPlugins()
+	if len(plugins) != 2 {
+		t.Fatalf("expecting 2 skipped plugins found %d", len(plugins))
+	}
+	if plugins[0].ArtifactId != "maven-surefire-plugin" {
+		t.Errorf("artifactId does not match (expected: maven-surefire-plugin, found: %s)", plugins[0].ArtifactId)
+	}
+	if plugins[1].ArtifactId != "maven-failsafe-plugin" {
+		t.Errorf("artifactId does not match (expected: maven-failsafe-plugin, found: %s)", plugins[1].ArtifactId)
+	}
+}
+
+func TestMavenProject_MainClass(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <main.class>com.example.Main</main.class>
+    </properties>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-jar-plugin</artifactId>
+                <version>3.2.0</version>
+                <configuration>
+                    <archive>
+                        <manifest>
+                            <addClasspath>true</addClasspath>
+                            <mainClass>${main.class}</mainClass>
+                        </manifest>
+                    </archive>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	mainClass, exist := project.MainClass()
+	if !exist || mainClass != "com.example.Main" {
+		t.Errorf("mainClass does not match (expected: com.example.Main, found: %s)", mainClass)
+	}
+
+	shadePomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-shade-plugin</artifactId>
+                <configuration>
+                    <transformers>
+                        <transformer implementation="org.apache.maven.plugins.shade.resource.ManifestResourceTransformer">
+                            <mainClass>com.example.ShadedMain</mainClass>
+                        </transformer>
+                    </transformers>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	project = MavenProject{}
+	if err := xml.Unmarshal([]byte(shadePomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+	if mainClass, exist := project.MainClass(); !exist || mainClass != "com.example.ShadedMain" {
+		t.Errorf("mainClass does not match (expected: com.example.ShadedMain, found: %s)", mainClass)
+	}
+
+	// shade is usually configured in its package execution
+	project.Build.Plugins[0].Executions = []Execution{{Id: "shade", Configuration: project.Build.Plugins[0].Configuration}}
+	project.Build.Plugins[0].Configuration = Config{}
+	if mainClass, exist := project.MainClass(); !exist || mainClass != "com.example.ShadedMain" {
+		t.Errorf("mainClass does not match (expected: com.example.ShadedMain, found: %s)", mainClass)
+	}
+
+	if _, exist := (&MavenProject{}).MainClass(); exist {
+		t.Error("expecting no main class")
+	}
+}
+
+func TestPlugin_ConfigKeys(t *testing.T) {
+	pluginStr := `
+<plugin>
+    <artifactId>maven-jar-plugin</artifactId>
+    <configuration>
+        <archive>
+            <manifest>
+                <addClasspath>true</addClasspath>
+                <mainClass>com.example.Main</mainClass>
+            </manifest>
+        </archive>
+        <excludes>
+            <exclude>**/*.bin</exclude>
+            <exclude>**/*.tmp</exclude>
+        </excludes>
+        <skipIfEmpty>true</skipIfEmpty>
+    </configuration>
+</plugin>`
+
+	var plugin Plugin
+	if err := xml.Unmarshal([]byte(pluginStr), &plugin); err != nil {
+		t.Fatalf("unable to unmarshal plugin. Reason: %s", err)
+	}
+
+	keys := plugin.ConfigKeys()
+	expected := []string{"archive.manifest.addClasspath", "archive.manifest.mainClass", "excludes.exclude", "skipIfEmpty"}
+	if len(keys) != len(expected) {
+		t.Fatalf("expecting %d keys found %d (%v)", len(expected), len(keys), keys)
+	}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("key[%d] does not match (expected: %s, found: %s)", i, key, keys[i])
+		}
+	}
+}
+
+func TestMavenProject_TestIncludes(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <configuration>
+                    <includes>
+                        <include>**/*Test.java</include>
+                        <include>**/*Spec.java</include>
+                    </includes>
+                    <excludes>
+                        <exclude>**/*IT.java</exclude>
+                    </excludes>
+                </configuration>
+            </plugin>
+            <plugin>
+                <artifactId>maven-failsafe-plugin</artifactId>
+                <configuration>
+                    <includes>
+                        <include>**/*IT.java</include>
+                    </includes>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	includes, excludes := project.TestIncludes()
+	expected := []string{"**/*Test.java", "**/*Spec.java", "**/*IT.java"}
+	if !reflect.DeepEqual(includes, expected) {
+		t.Errorf("includes do not match (expected: %v, found: %v)", expected, includes)
+	}
+	expected = []string{"**/*IT.java"}
+	if !reflect.DeepEqual(excludes, expected) {
+		t.Errorf("excludes do not match (expected: %v, found: %v)", expected, excludes)
+	}
+}
+
+func TestMavenProject_CompilerConfig(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <java.version>11</java.version>
+        <maven.compiler.target>11</maven.compiler.target>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+    </properties>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <source>${java.version}</source>
+                    <release>${java.version}</release>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	source, target, release, encoding := project.CompilerConfig()
+	if source != "11" {
+		t.Errorf("source does not match (expected: 11, found: %s)", source)
+	}
+	if target != "11" {
+		t.Errorf("target does not match (expected: 11, found: %s)", target)
+	}
+	if release != "11" {
+		t.Errorf("release does not match (expected: 11, found: %s)", release)
+	}
+	if encoding != "UTF-8" {
+		t.Errorf("encoding does not match (expected: UTF-8, found: %s)", encoding)
+	}
+}
+
+func TestMavenProject_SurefireConfig(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <configuration>
+                    <skipTests>true</skipTests>
+                    <argLine>-Xmx1g</argLine>
+                    <includes>
+                        <include>**/*Test.java</include>
+                    </includes>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	skip, argLine, includes := project.SurefireConfig()
+	if !skip {
+		t.Error("expecting tests to be skipped")
+	}
+	if argLine != "-Xmx1g" {
+		t.Errorf("argLine does not match (expected: -Xmx1g, found: %s)", argLine)
+	}
+	expected := []string{"**/*Test.java"}
+	if !reflect.DeepEqual(includes, expected) {
+		t.Errorf("includes do not match (expected: %v, found: %v)", expected, includes)
+	}
+
+	skip, argLine, includes = (&MavenProject{}).SurefireConfig()
+	if skip || argLine != "" || len(includes) != 0 {
+		t.Errorf("expecting default surefire configuration, found %v %s %v", skip, argLine, includes)
+	}
+}
+
+func TestMavenProject_ExecutionConfig(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-antrun-plugin</artifactId>
+                <configuration>
+                    <skip>false</skip>
+                    <failOnError>true</failOnError>
+                </configuration>
+                <executions>
+                    <execution>
+                        <id>generate</id>
+                        <phase>generate-sources</phase>
+                        <configuration>
+                            <target>generate</target>
+                        </configuration>
+                    </execution>
+                    <execution>
+                        <id>package</id>
+                        <phase>package</phase>
+                        <configuration>
+                            <target>package</target>
+                            <failOnError>false</failOnError>
+                        </configuration>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	config, exist := project.ExecutionConfig("org.apache.maven.plugins:maven-antrun-plugin", "generate")
+	if !exist {
+		t.Fatal("expecting generate execution to exist")
+	}
+	if target, _ := config.Lookup("target"); target != "generate" {
+		t.Errorf("target does not match (expected: generate, found: %s)", target)
+	}
+	if failOnError, _ := config.Lookup("failOnError"); failOnError != "true" {
+		t.Errorf("inherited failOnError does not match (expected: true, found: %s)", failOnError)
+	}
+
+	config, exist = project.ExecutionConfig("maven-antrun-plugin", "package")
+	if !exist {
+		t.Fatal("expecting package execution to exist")
+	}
+	if target, _ := config.Lookup("target"); target != "package" {
+		t.Errorf("target does not match (expected: package, found: %s)", target)
+	}
+	if failOnError, _ := config.Lookup("failOnError"); failOnError != "false" {
+		t.Errorf("failOnError does not match (expected: false, found: %s)", failOnError)
+	}
+
+	if _, exist := project.ExecutionConfig("maven-antrun-plugin", "deploy"); exist {
+		t.Error("expecting deploy execution not to exist")
+	}
+}
+
+func TestMavenProject_RequiredJavaRelease(t *testing.T) {
+	tests := []struct {
+		project  MavenProject
+		expected int
+		found    bool
+	}{
+		{
+			project:  MavenProject{Properties: Properties{"maven.compiler.release": "17"}},
+			expected: 17,
+			found:    true,
+		},
+		{
+			project:  MavenProject{Properties: Properties{"maven.compiler.source": "11", "maven.compiler.target": "11"}},
+			expected: 11,
+			found:    true,
+		},
+		{
+			project:  MavenProject{Properties: Properties{"maven.compiler.source": "1.7", "maven.compiler.target": "1.8"}},
+			expected: 8,
+			found:    true,
+		},
+		{
+			project: MavenProject{},
+			found:   false,
+		},
+	}
+
+	for _, test := range tests {
+		release, found := test.project.RequiredJavaRelease()
+		if release != test.expected || found != test.found {
+			t.Errorf("release does not match (expected: %d %v, found: %d %v)", test.expected, test.found, release, found)
+		}
+	}
+}
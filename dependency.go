@@ -0,0 +1,414 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// EffectiveType return the type of the dependency, defaulting to jar
+func (d Dependency) EffectiveType() string {
+	if d.Type == "" {
+		return "jar"
+	}
+	return d.Type
+}
+
+// EffectiveScope return the scope of the dependency, defaulting to compile
+func (d Dependency) EffectiveScope() string {
+	if d.Scope == "" {
+		return "compile"
+	}
+	return d.Scope
+}
+
+// EffectiveClassifier return the classifier of the dependency, defaulting to the classifier
+// implied by its type in TypeClassifiers (e.g. tests for a test-jar)
+func (d Dependency) EffectiveClassifier() string {
+	if d.Classifier != "" {
+		return d.Classifier
+	}
+	return TypeClassifiers[d.EffectiveType()]
+}
+
+// IsProvided return true if the dependency is expected to be provided by the JDK or container
+func (d Dependency) IsProvided() bool {
+	return d.EffectiveScope() == "provided"
+}
+
+// SameArtifact return true if both dependencies target the same artifact, ignoring version and scope
+func (d Dependency) SameArtifact(other Dependency) bool {
+	return d.GroupId == other.GroupId &&
+		d.ArtifactId == other.ArtifactId &&
+		d.EffectiveType() == other.EffectiveType() &&
+		d.Classifier == other.Classifier
+}
+
+// ApplyDependencyManagement return a copy of dep with the version, scope and exclusions
+// it does not declare filled from the matching dependencyManagement entry. Wildcard
+// exclusions of the management entry are added even if dep declare its own exclusions.
+func (mp *MavenProject) ApplyDependencyManagement(dep Dependency) Dependency {
+	for _, managed := range mp.DependencyManagement.Dependencies {
+		if !dep.SameArtifact(managed) {
+			continue
+		}
+		if dep.Version == "" {
+			dep.Version = managed.Version
+		}
+		if dep.Scope == "" {
+			dep.Scope = managed.Scope
+		}
+		if len(dep.Exclusions) == 0 {
+			dep.Exclusions = managed.Exclusions
+		} else {
+			// wildcard exclusions of the management always apply
+			exclusions := append([]Exclusion{}, dep.Exclusions...)
+			for _, exclusion := range managed.Exclusions {
+				if exclusion.isWildcard() && !containsExclusion(exclusions, exclusion) {
+					exclusions = append(exclusions, exclusion)
+				}
+			}
+			dep.Exclusions = exclusions
+		}
+		break
+	}
+	return dep
+}
+
+// ResolvedDependencies return the dependencies of the project with dependencyManagement
+// applied and properties interpolated
+func (mp *MavenProject) ResolvedDependencies() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.Dependencies {
+		deps = append(deps, mp.resolveDependency(mp.ApplyDependencyManagement(dep)))
+	}
+	return deps
+}
+
+func (mp *MavenProject) resolveDependency(dep Dependency) Dependency {
+	dep.GroupId = mp.Interpolate(dep.GroupId)
+	dep.ArtifactId = mp.Interpolate(dep.ArtifactId)
+	dep.Version = mp.Interpolate(dep.Version)
+	dep.Classifier = mp.Interpolate(dep.Classifier)
+	dep.Type = mp.Interpolate(dep.Type)
+	dep.Scope = mp.Interpolate(dep.Scope)
+	return dep
+}
+
+// ProvidedDependencies return the resolved dependencies of the project with provided scope
+func (mp *MavenProject) ProvidedDependencies() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.ResolvedDependencies() {
+		if dep.IsProvided() {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// BuildTimeOnlyDependencies return the resolved dependencies only needed to build the project, which
+// are not part of its runtime classpath: the provided, test and system scoped ones
+func (mp *MavenProject) BuildTimeOnlyDependencies() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.ResolvedDependencies() {
+		switch dep.EffectiveScope() {
+		case "provided", "test", "system":
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// key return the groupId:artifactId:type[:classifier] identifying the artifact of the dependency
+func (d Dependency) key() string {
+	key := d.GroupId + ":" + d.ArtifactId + ":" + d.EffectiveType()
+	if d.Classifier != "" {
+		key += ":" + d.Classifier
+	}
+	return key
+}
+
+// DependenciesByGroup return the resolved dependencies of the project grouped by groupId
+func (mp *MavenProject) DependenciesByGroup() map[string][]Dependency {
+	groups := map[string][]Dependency{}
+	for _, dep := range mp.ResolvedDependencies() {
+		groups[dep.GroupId] = append(groups[dep.GroupId], dep)
+	}
+	return groups
+}
+
+// SortedGroups return the groupIds of groups (as returned by DependenciesByGroup) in sorted order
+func SortedGroups(groups map[string][]Dependency) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ResolveImportedBOMs resolve the dependencyManagement entries with import scope using resolver and
+// add the dependencies managed by those BOMs (including their own imports) to the dependencyManagement
+// of the project. Entries declared locally take precedence over imported ones.
+func (mp *MavenProject) ResolveImportedBOMs(resolver ParentResolver) error {
+	return mp.resolveImportedBOMs(resolver, map[string]bool{})
+}
+
+func (mp *MavenProject) resolveImportedBOMs(resolver ParentResolver, seen map[string]bool) error {
+	var imports []Dependency
+	for _, managed := range mp.DependencyManagement.Dependencies {
+		if managed.Scope == "import" && managed.EffectiveType() == "pom" {
+			imports = append(imports, mp.resolveDependency(managed))
+		}
+	}
+
+	for _, bomDep := range imports {
+		coordinates := bomDep.GroupId + ":" + bomDep.ArtifactId + ":" + bomDep.Version
+		if seen[coordinates] {
+			continue
+		}
+		seen[coordinates] = true
+
+		bom, err := resolvePOM(resolver, bomDep.GroupId, bomDep.ArtifactId, bomDep.Version)
+		if err != nil {
+			return fmt.Errorf("can't resolve imported bom %s, %v", coordinates, err)
+		}
+		if bom, err = bom.EffectivePOM(resolver); err != nil {
+			return fmt.Errorf("can't resolve imported bom %s, %v", coordinates, err)
+		}
+		if err := bom.resolveImportedBOMs(resolver, seen); err != nil {
+			return err
+		}
+
+		for _, managed := range bom.DependencyManagement.Dependencies {
+			if managed.Scope == "import" {
+				continue
+			}
+			managed = bom.resolveDependency(managed)
+
+			declared := false
+			for _, existing := range mp.DependencyManagement.Dependencies {
+				if existing.SameArtifact(managed) {
+					declared = true
+					break
+				}
+			}
+			if !declared {
+				mp.DependencyManagement.Dependencies = append(mp.DependencyManagement.Dependencies, managed)
+				mp.importedManagement = append(mp.importedManagement, managed)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseBOM parse a bill of materials POM and return the versions it manages keyed by groupId:artifactId,
+// properties interpolated. The BOMs it imports itself are not resolved. An error is returned if the
+// document is not a BOM: a pom packaged project with a dependencyManagement.
+func ParseBOM(r io.Reader) (map[string]string, error) {
+	pf, err := ParseReader(r)
+	if err != nil {
+		return nil, err
+	}
+	bom := pf.Project
+	if packaging, _ := bom.lookupProperty("project.packaging"); packaging != "pom" {
+		return nil, fmt.Errorf("can't parse bom %s, packaging %s is not pom", bom.ArtifactId, packaging)
+	}
+	if len(bom.DependencyManagement.Dependencies) == 0 {
+		return nil, fmt.Errorf("can't parse bom %s, no dependencyManagement", bom.ArtifactId)
+	}
+
+	versions := map[string]string{}
+	for _, managed := range bom.DependencyManagement.Dependencies {
+		if managed.Scope == "import" {
+			continue
+		}
+		managed = bom.resolveDependency(managed)
+		versions[managed.GroupId+":"+managed.ArtifactId] = managed.Version
+	}
+	return versions, nil
+}
+
+// ApplyBOMVersions fill the version of the dependencies declaring none from bom, keyed by
+// groupId:artifactId as returned by ParseBOM, and return the number of dependencies updated.
+// As for an imported BOM, the versions of the dependencyManagement take precedence.
+func (mp *MavenProject) ApplyBOMVersions(bom map[string]string) int {
+	updated := 0
+	for i, dep := range mp.Dependencies {
+		if dep.Version != "" || mp.ApplyDependencyManagement(dep).Version != "" {
+			continue
+		}
+		if version, exist := bom[dep.GroupId+":"+dep.ArtifactId]; exist {
+			mp.Dependencies[i].Version = version
+			updated++
+		}
+	}
+	return updated
+}
+
+// Represent a dependency whose version would change, the old version being empty when it is filled
+type VersionChange struct {
+	GroupId    string
+	ArtifactId string
+	OldVersion string
+	NewVersion string
+}
+
+// BOMImpact preview the effect of governing the dependencies without explicit version by bom, keyed by
+// groupId:artifactId as returned by ParseBOM: the versions it would fill, and the ones supplied by the
+// current dependencyManagement it would change if it took precedence (ApplyBOMVersions only fills).
+func (mp *MavenProject) BOMImpact(bom map[string]string) []VersionChange {
+	var changes []VersionChange
+	for _, dep := range mp.Dependencies {
+		if dep.Version != "" {
+			continue
+		}
+		resolved := mp.resolveDependency(mp.ApplyDependencyManagement(dep))
+		version, exist := bom[resolved.GroupId+":"+resolved.ArtifactId]
+		if exist && version != resolved.Version {
+			changes = append(changes, VersionChange{
+				GroupId:    resolved.GroupId,
+				ArtifactId: resolved.ArtifactId,
+				OldVersion: resolved.Version,
+				NewVersion: version,
+			})
+		}
+	}
+	return changes
+}
+
+// ImportedManagedDependencies return the dependencyManagement entries contributed by imported BOMs
+// during ResolveImportedBOMs, as opposed to the ones declared locally
+func (mp *MavenProject) ImportedManagedDependencies() []Dependency {
+	return mp.importedManagement
+}
+
+// Excludes return true if the dependency exclusions prevent the transitive artifact
+// groupId:artifactId from being included, honoring the * wildcard
+func (d Dependency) Excludes(groupId, artifactId string) bool {
+	for _, exclusion := range d.Exclusions {
+		if (exclusion.GroupId == "*" || exclusion.GroupId == groupId) &&
+			(exclusion.ArtifactId == "*" || exclusion.ArtifactId == artifactId) {
+			return true
+		}
+	}
+	return false
+}
+
+// WildcardExclusions return the exclusions of the dependencies, managed dependencies and profiles
+// using the * wildcard in their groupId or artifactId, only supported since maven 3.2.1
+func (mp *MavenProject) WildcardExclusions() []Exclusion {
+	var exclusions []Exclusion
+	add := func(deps []Dependency) {
+		for _, dep := range deps {
+			for _, exclusion := range dep.Exclusions {
+				if exclusion.isWildcard() {
+					exclusions = append(exclusions, exclusion)
+				}
+			}
+		}
+	}
+
+	add(mp.DependencyManagement.Dependencies)
+	add(mp.Dependencies)
+	for _, profile := range mp.Profiles {
+		add(profile.DependencyManagement.Dependencies)
+		add(profile.Dependencies)
+	}
+	return exclusions
+}
+
+// isWildcard return true if the exclusion use the * wildcard
+func (e Exclusion) isWildcard() bool {
+	return e.GroupId == "*" || e.ArtifactId == "*"
+}
+
+func containsExclusion(exclusions []Exclusion, exclusion Exclusion) bool {
+	for _, e := range exclusions {
+		if e.GroupId == exclusion.GroupId && e.ArtifactId == exclusion.ArtifactId {
+			return true
+		}
+	}
+	return false
+}
+
+// UnprunedDependencies return the resolved dependencies (dependencyManagement applied) without any
+// exclusion, which bring their whole transitive tree on the classpath
+func (mp *MavenProject) UnprunedDependencies() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.ResolvedDependencies() {
+		if len(dep.Exclusions) == 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// ExclusionSet return the groupId:artifactId of every exclusion of the resolved dependencies
+// (dependencyManagement applied), wildcards included as declared (e.g. org.slf4j:* or *:*)
+func (mp *MavenProject) ExclusionSet() map[string]bool {
+	set := map[string]bool{}
+	for _, dep := range mp.ResolvedDependencies() {
+		for _, exclusion := range dep.Exclusions {
+			set[exclusion.GroupId+":"+exclusion.ArtifactId] = true
+		}
+	}
+	return set
+}
+
+// Sections of a project or profile declaring dependencies
+const (
+	OriginMain       = "dependencies"
+	OriginManagement = "dependencyManagement"
+)
+
+// Represent where a dependency is declared: the dependencies or the dependencyManagement
+// section of the project itself, or of one of its profiles
+type DependencyOrigin struct {
+	// OriginMain or OriginManagement
+	Section string
+	// The id of the declaring profile, empty when declared by the project itself
+	ProfileId string
+}
+
+// DependencyOrigins partition the dependencies of the project, as declared, by the section that declares them.
+// Sections without any dependency are omitted.
+func (mp *MavenProject) DependencyOrigins() map[DependencyOrigin][]Dependency {
+	origins := map[DependencyOrigin][]Dependency{}
+	add := func(origin DependencyOrigin, deps []Dependency) {
+		if len(deps) > 0 {
+			origins[origin] = append(origins[origin], deps...)
+		}
+	}
+
+	add(DependencyOrigin{Section: OriginMain}, mp.Dependencies)
+	add(DependencyOrigin{Section: OriginManagement}, mp.DependencyManagement.Dependencies)
+	for _, profile := range mp.Profiles {
+		add(DependencyOrigin{Section: OriginMain, ProfileId: profile.Id}, profile.Dependencies)
+		add(DependencyOrigin{Section: OriginManagement, ProfileId: profile.Id}, profile.DependencyManagement.Dependencies)
+	}
+	return origins
+}
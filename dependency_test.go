@@ -0,0 +1,477 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDependency_SameArtifact(t *testing.T) {
+	a := Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.22"}
+	b := Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30", Type: "jar", Scope: "test"}
+	if !a.SameArtifact(b) {
+		t.Errorf("expecting %v and %v to be the same artifact", a, b)
+	}
+
+	c := Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.22", Classifier: "sources"}
+	if a.SameArtifact(c) {
+		t.Errorf("expecting %v and %v to be different artifacts", a, c)
+	}
+}
+
+func TestDependency_EffectiveClassifier(t *testing.T) {
+	explicit := Dependency{GroupId: "org.example", ArtifactId: "core", Type: "test-jar", Classifier: "test-fixtures"}
+	if explicit.EffectiveClassifier() != "test-fixtures" {
+		t.Errorf("classifier does not match (expected: test-fixtures, found: %s)", explicit.EffectiveClassifier())
+	}
+
+	testJar := Dependency{GroupId: "org.example", ArtifactId: "core", Type: "test-jar"}
+	if testJar.EffectiveClassifier() != "tests" {
+		t.Errorf("classifier does not match (expected: tests, found: %s)", testJar.EffectiveClassifier())
+	}
+
+	jar := Dependency{GroupId: "org.example", ArtifactId: "core"}
+	if jar.EffectiveClassifier() != "" {
+		t.Errorf("classifier does not match (expected: empty, found: %s)", jar.EffectiveClassifier())
+	}
+}
+
+func TestDependency_IsProvided(t *testing.T) {
+	provided := Dependency{GroupId: "javax.enterprise", ArtifactId: "cdi-api", Scope: "provided"}
+	if !provided.IsProvided() {
+		t.Errorf("expecting %s to be provided", provided.ArtifactId)
+	}
+
+	compile := Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api"}
+	if compile.IsProvided() {
+		t.Errorf("expecting %s not to be provided", compile.ArtifactId)
+	}
+	if compile.EffectiveScope() != "compile" {
+		t.Errorf("scope does not match (expected: compile, found: %s)", compile.EffectiveScope())
+	}
+
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "javax.persistence", ArtifactId: "javax.persistence-api", Scope: "provided"},
+		}},
+		Dependencies: []Dependency{
+			provided,
+			compile,
+			{GroupId: "javax.persistence", ArtifactId: "javax.persistence-api"},
+		},
+	}
+	deps := project.ProvidedDependencies()
+	if len(deps) != 2 {
+		t.Fatalf("expecting 2 provided dependencies found %d", len(deps))
+	}
+	if deps[1].ArtifactId != "javax.persistence-api" {
+		t.Errorf("artifactId does not match (expected: javax.persistence-api, found: %s)", deps[1].ArtifactId)
+	}
+}
+
+func TestMavenProject_BuildTimeOnlyDependencies(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.mockito", ArtifactId: "mockito-core", Scope: "test"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "javax.servlet", ArtifactId: "servlet-api", Scope: "provided"},
+			{GroupId: "ch.qos.logback", ArtifactId: "logback-classic", Scope: "runtime"},
+			{GroupId: "junit", ArtifactId: "junit", Scope: "test"},
+			{GroupId: "com.sun", ArtifactId: "tools", Scope: "system"},
+			{GroupId: "com.google.guava", ArtifactId: "guava", Scope: "compile"},
+			{GroupId: "org.mockito", ArtifactId: "mockito-core"},
+		},
+	}
+
+	var artifactIds []string
+	for _, dep := range project.BuildTimeOnlyDependencies() {
+		artifactIds = append(artifactIds, dep.ArtifactId)
+	}
+	expected := []string{"servlet-api", "junit", "tools", "mockito-core"}
+	if !reflect.DeepEqual(artifactIds, expected) {
+		t.Errorf("build time dependencies does not match (expected: %v, found: %v)", expected, artifactIds)
+	}
+}
+
+func TestMavenProject_DependenciesByGroup(t *testing.T) {
+	project := MavenProject{
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-simple"},
+		},
+	}
+
+	groups := project.DependenciesByGroup()
+	if len(groups["org.slf4j"]) != 2 {
+		t.Errorf("expecting 2 dependencies in org.slf4j found %d", len(groups["org.slf4j"]))
+	}
+	if len(groups["com.fasterxml.jackson.core"]) != 1 {
+		t.Errorf("expecting 1 dependency in com.fasterxml.jackson.core found %d", len(groups["com.fasterxml.jackson.core"]))
+	}
+
+	keys := SortedGroups(groups)
+	if len(keys) != 2 || keys[0] != "com.fasterxml.jackson.core" || keys[1] != "org.slf4j" {
+		t.Errorf("sorted groups does not match (expected: [com.fasterxml.jackson.core org.slf4j], found: %v)", keys)
+	}
+}
+
+func TestMavenProject_ImportedManagedDependencies(t *testing.T) {
+	resolver := mapResolver{
+		"com.example:bom:1.0.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>bom</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.example</groupId>
+                <artifactId>core</artifactId>
+                <version>${project.version}</version>
+            </dependency>
+            <dependency>
+                <groupId>com.example</groupId>
+                <artifactId>web</artifactId>
+                <version>${project.version}</version>
+            </dependency>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.11</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`,
+	}
+
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "com.example", ArtifactId: "bom", Version: "1.0.0", Type: "pom", Scope: "import"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "com.example", ArtifactId: "core"},
+		},
+	}
+
+	if err := project.ResolveImportedBOMs(resolver); err != nil {
+		t.Fatalf("unable to resolve imported boms. Reason: %s", err)
+	}
+
+	imported := project.ImportedManagedDependencies()
+	if len(imported) != 2 {
+		t.Fatalf("expecting 2 imported managed dependencies found %d", len(imported))
+	}
+	if imported[0].ArtifactId != "core" || imported[0].Version != "1.0.0" {
+		t.Errorf("imported[0] does not match (expected: core:1.0.0, found: %s:%s)", imported[0].ArtifactId, imported[0].Version)
+	}
+	if imported[1].ArtifactId != "web" {
+		t.Errorf("imported[1] does not match (expected: web, found: %s)", imported[1].ArtifactId)
+	}
+
+	deps := project.ResolvedDependencies()
+	if deps[0].Version != "1.0.0" {
+		t.Errorf("version does not match (expected: 1.0.0, found: %s)", deps[0].Version)
+	}
+}
+
+func TestMavenProject_ApplyDependencyManagement_WildcardExclusions(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{
+				GroupId:    "io.swagger.core.v3",
+				ArtifactId: "swagger-jaxrs2",
+				Version:    "2.0.8",
+				Exclusions: []Exclusion{
+					{GroupId: "com.fasterxml.jackson.core", ArtifactId: "*"},
+					{GroupId: "javax.ws.rs", ArtifactId: "jsr311-api"},
+				},
+			},
+		}},
+	}
+
+	dep := project.ApplyDependencyManagement(Dependency{
+		GroupId:    "io.swagger.core.v3",
+		ArtifactId: "swagger-jaxrs2",
+		Exclusions: []Exclusion{{GroupId: "org.slf4j", ArtifactId: "slf4j-api"}},
+	})
+
+	if len(dep.Exclusions) != 2 {
+		t.Fatalf("expecting 2 exclusions found %d", len(dep.Exclusions))
+	}
+	if !dep.Excludes("com.fasterxml.jackson.core", "jackson-databind") {
+		t.Error("expecting jackson-databind to be excluded by the managed wildcard")
+	}
+	if !dep.Excludes("org.slf4j", "slf4j-api") {
+		t.Error("expecting slf4j-api to be excluded")
+	}
+	if dep.Excludes("javax.ws.rs", "jsr311-api") {
+		t.Error("expecting jsr311-api not to be excluded since the dependency declare its own exclusions")
+	}
+
+	all := Dependency{Exclusions: []Exclusion{{GroupId: "*", ArtifactId: "*"}}}
+	if !all.Excludes("org.example", "anything") {
+		t.Error("expecting *:* to exclude everything")
+	}
+}
+
+func TestMavenProject_DependencyOrigins(t *testing.T) {
+	project := MavenProject{
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "junit", ArtifactId: "junit", Scope: "test"},
+		},
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+		}},
+		Profiles: []Profile{
+			{Id: "logback", Dependencies: []Dependency{
+				{GroupId: "ch.qos.logback", ArtifactId: "logback-classic", Version: "1.2.3"},
+			}},
+			{Id: "empty"},
+		},
+	}
+
+	origins := project.DependencyOrigins()
+	if len(origins) != 3 {
+		t.Fatalf("expecting 3 origins found %d", len(origins))
+	}
+	if deps := origins[DependencyOrigin{Section: OriginMain}]; len(deps) != 2 {
+		t.Errorf("expecting 2 main dependencies found %d", len(deps))
+	}
+	if deps := origins[DependencyOrigin{Section: OriginManagement}]; len(deps) != 1 {
+		t.Errorf("expecting 1 managed dependency found %d", len(deps))
+	}
+	deps := origins[DependencyOrigin{Section: OriginMain, ProfileId: "logback"}]
+	if len(deps) != 1 || deps[0].ArtifactId != "logback-classic" {
+		t.Errorf("profile dependencies do not match (expected: [logback-classic], found: %v)", deps)
+	}
+}
+
+func TestMavenProject_UnprunedDependencies(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.apache.zookeeper", ArtifactId: "zookeeper", Exclusions: []Exclusion{
+				{GroupId: "log4j", ArtifactId: "log4j"},
+			}},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "org.apache.hadoop", ArtifactId: "hadoop-common", Exclusions: []Exclusion{
+				{GroupId: "log4j", ArtifactId: "log4j"},
+			}},
+			{GroupId: "org.apache.zookeeper", ArtifactId: "zookeeper"},
+			{GroupId: "org.apache.spark", ArtifactId: "spark-core_2.12"},
+		},
+	}
+
+	deps := project.UnprunedDependencies()
+	if len(deps) != 1 {
+		t.Fatalf("expecting 1 unpruned dependency found %d", len(deps))
+	}
+	if deps[0].ArtifactId != "spark-core_2.12" {
+		t.Errorf("artifactId does not match (expected: spark-core_2.12, found: %s)", deps[0].ArtifactId)
+	}
+}
+
+func TestMavenProject_ExclusionSet(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{
+				GroupId:    "io.swagger.core.v3",
+				ArtifactId: "swagger-jaxrs2",
+				Version:    "2.0.8",
+				Exclusions: []Exclusion{{GroupId: "com.fasterxml.jackson.core", ArtifactId: "*"}},
+			},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "io.swagger.core.v3", ArtifactId: "swagger-jaxrs2"},
+			{
+				GroupId:    "org.apache.hadoop",
+				ArtifactId: "hadoop-client",
+				Version:    "3.3.0",
+				Exclusions: []Exclusion{{GroupId: "log4j", ArtifactId: "log4j"}},
+			},
+		},
+	}
+
+	set := project.ExclusionSet()
+	if len(set) != 2 {
+		t.Errorf("expecting 2 exclusions found %d", len(set))
+	}
+	if !set["log4j:log4j"] {
+		t.Error("expecting log4j:log4j to be excluded")
+	}
+	if !set["com.fasterxml.jackson.core:*"] {
+		t.Error("expecting com.fasterxml.jackson.core:* to be excluded")
+	}
+	if set["org.slf4j:slf4j-api"] {
+		t.Error("expecting org.slf4j:slf4j-api not to be excluded")
+	}
+}
+
+func TestParseBOM(t *testing.T) {
+	bom, err := ParseBOM(strings.NewReader(`
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>bom</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <jackson.version>2.10.0</jackson.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.fasterxml.jackson.core</groupId>
+                <artifactId>jackson-databind</artifactId>
+                <version>${jackson.version}</version>
+            </dependency>
+            <dependency>
+                <groupId>org.example</groupId>
+                <artifactId>core</artifactId>
+                <version>${project.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"com.fasterxml.jackson.core:jackson-databind": "2.10.0",
+		"org.example:core": "1.0.0",
+	}
+	if !reflect.DeepEqual(bom, expected) {
+		t.Errorf("bom does not match (expected: %v, found: %v)", expected, bom)
+	}
+
+	_, err = ParseBOM(strings.NewReader(`
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1.0.0</version>
+</project>`))
+	if err == nil || err.Error() != "can't parse bom app, packaging jar is not pom" {
+		t.Errorf("expecting a packaging error, found %v", err)
+	}
+}
+
+func TestMavenProject_ApplyBOMVersions(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.22"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+			{GroupId: "com.google.guava", ArtifactId: "guava"},
+		},
+	}
+	bom := map[string]string{
+		"com.fasterxml.jackson.core:jackson-databind": "2.10.0",
+		"org.slf4j:slf4j-api":                         "1.7.30",
+		"junit:junit":                                 "4.13",
+	}
+
+	if updated := project.ApplyBOMVersions(bom); updated != 1 {
+		t.Errorf("updated count does not match (expected: 1, found: %d)", updated)
+	}
+	for i, expected := range []string{"2.10.0", "", "4.12", ""} {
+		if project.Dependencies[i].Version != expected {
+			t.Errorf("dependency[%d] version does not match (expected: %s, found: %s)", i, expected, project.Dependencies[i].Version)
+		}
+	}
+}
+
+func TestMavenProject_BOMImpact(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.22"},
+			{GroupId: "ch.qos.logback", ArtifactId: "logback-classic", Version: "1.2.3"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+			{GroupId: "com.google.guava", ArtifactId: "guava"},
+			{GroupId: "ch.qos.logback", ArtifactId: "logback-classic"},
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-core"},
+		},
+	}
+	bom := map[string]string{
+		"com.fasterxml.jackson.core:jackson-databind": "2.10.0",
+		"com.fasterxml.jackson.core:jackson-core":     "2.10.0",
+		"org.slf4j:slf4j-api":                         "1.7.30",
+		"ch.qos.logback:logback-classic":              "1.2.3",
+		"junit:junit":                                 "4.13",
+	}
+
+	expected := []VersionChange{
+		{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind", NewVersion: "2.10.0"},
+		{GroupId: "org.slf4j", ArtifactId: "slf4j-api", OldVersion: "1.7.22", NewVersion: "1.7.30"},
+		{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-core", NewVersion: "2.10.0"},
+	}
+	if changes := project.BOMImpact(bom); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("changes does not match (expected: %v, found: %v)", expected, changes)
+	}
+	if project.Dependencies[0].Version != "" {
+		t.Errorf("expecting the project to be left unchanged, found version %s", project.Dependencies[0].Version)
+	}
+}
+
+func TestMavenProject_WildcardExclusions(t *testing.T) {
+	project := MavenProject{
+		Dependencies: []Dependency{
+			{
+				GroupId:    "org.apache.hadoop",
+				ArtifactId: "hadoop-client",
+				Exclusions: []Exclusion{
+					{GroupId: "*", ArtifactId: "*"},
+				},
+			},
+			{
+				GroupId:    "org.apache.spark",
+				ArtifactId: "spark-core_2.12",
+				Exclusions: []Exclusion{
+					{GroupId: "log4j", ArtifactId: "log4j"},
+				},
+			},
+		},
+	}
+
+	exclusions := project.WildcardExclusions()
+	if len(exclusions) != 1 {
+		t.Fatalf("expecting 1 wildcard exclusion found %d", len(exclusions))
+	}
+	if exclusions[0].GroupId != "*" || exclusions[0].ArtifactId != "*" {
+		t.Errorf("exclusion does not match (expected: *:*, found: %s:%s)", exclusions[0].GroupId, exclusions[0].ArtifactId)
+	}
+}
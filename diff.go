@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+// Represent the plugin changes between two projects
+type PluginDiff struct {
+	Added   []Plugin
+	Removed []Plugin
+	Changed []PluginChange
+}
+
+// Represent a plugin whose version changed between two projects
+type PluginChange struct {
+	GroupId    string
+	ArtifactId string
+	OldVersion string
+	NewVersion string
+}
+
+// DiffPlugins report the build plugins added, removed and whose version changed from a to b,
+// matching plugins by groupId:artifactId once pluginManagement is applied
+func DiffPlugins(a, b *MavenProject) PluginDiff {
+	var diff PluginDiff
+
+	before := map[string]Plugin{}
+	for _, plugin := range a.ResolvedPlugins() {
+		before[plugin.key()] = plugin
+	}
+	after := map[string]bool{}
+
+	for _, plugin := range b.ResolvedPlugins() {
+		after[plugin.key()] = true
+		previous, exist := before[plugin.key()]
+		if !exist {
+			diff.Added = append(diff.Added, plugin)
+		} else if previous.Version != plugin.Version {
+			diff.Changed = append(diff.Changed, PluginChange{
+				GroupId:    plugin.EffectiveGroupId(),
+				ArtifactId: plugin.ArtifactId,
+				OldVersion: previous.Version,
+				NewVersion: plugin.Version,
+			})
+		}
+	}
+
+	for _, plugin := range a.ResolvedPlugins() {
+		if !after[plugin.key()] {
+			diff.Removed = append(diff.Removed, plugin)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,53 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import "testing"
+
+func TestDiffPlugins(t *testing.T) {
+	a := &MavenProject{Build: Build{Plugins: []Plugin{
+		{ArtifactId: "maven-compiler-plugin", Version: "3.8.0"},
+		{ArtifactId: "maven-war-plugin", Version: "3.2.2"},
+		{GroupId: "org.codehaus.mojo", ArtifactId: "exec-maven-plugin", Version: "1.6.0"},
+	}}}
+	b := &MavenProject{Build: Build{Plugins: []Plugin{
+		{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-compiler-plugin", Version: "3.8.1"},
+		{ArtifactId: "maven-war-plugin", Version: "3.2.2"},
+		{GroupId: "org.jacoco", ArtifactId: "jacoco-maven-plugin", Version: "0.8.2"},
+	}}}
+
+	diff := DiffPlugins(a, b)
+	if len(diff.Added) != 1 || diff.Added[0].ArtifactId != "jacoco-maven-plugin" {
+		t.Errorf("added plugins does not match (expected: [jacoco-maven-plugin], found: %v)", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ArtifactId != "exec-maven-plugin" {
+		t.Errorf("removed plugins does not match (expected: [exec-maven-plugin], found: %v)", diff.Removed)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("expecting 1 changed plugin found %d", len(diff.Changed))
+	}
+	change := diff.Changed[0]
+	if change.ArtifactId != "maven-compiler-plugin" || change.OldVersion != "3.8.0" || change.NewVersion != "3.8.1" {
+		t.Errorf("changed plugin does not match (expected: maven-compiler-plugin 3.8.0 -> 3.8.1, found: %v)", change)
+	}
+}
@@ -0,0 +1,344 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MinimalPOM return a new project containing only the coordinates, packaging and resolved
+// dependencies of the project, suitable for publishing alongside the artifact (consumer POM)
+func (mp *MavenProject) MinimalPOM() *MavenProject {
+	return &MavenProject{
+		ModelVersion: mp.ModelVersion,
+		GroupId:      mp.Interpolate(mp.EffectiveGroupId()),
+		ArtifactId:   mp.Interpolate(mp.ArtifactId),
+		Version:      mp.Interpolate(mp.EffectiveVersion()),
+		Packaging:    mp.Packaging,
+		Dependencies: mp.ResolvedDependencies(),
+	}
+}
+
+// EffectivePOM return the project with its whole parent chain, located using resolver, merged into it
+func (mp *MavenProject) EffectivePOM(resolver ParentResolver) (*MavenProject, error) {
+	return (&ParsedFile{Project: mp}).effectivePOM(nil, resolver, map[string]bool{})
+}
+
+// EffectivePOM return the project of the file with its whole parent chain merged into it, the
+// profiles active in given context being applied to each POM of the chain before it is inherited.
+// Parents are looked up on the filesystem at their relativePath first, then using resolver (see
+// ParsedFile.ResolveParent).
+func (pf *ParsedFile) EffectivePOM(ctx ActivationContext, resolver ParentResolver) (*MavenProject, error) {
+	return pf.effectivePOM(&ctx, resolver, map[string]bool{})
+}
+
+// effectivePOM merge the parent chain into the project, applying the active profiles of each POM
+// when ctx is given
+func (pf *ParsedFile) effectivePOM(ctx *ActivationContext, resolver ParentResolver, seen map[string]bool) (*MavenProject, error) {
+	project := pf.Project
+	if ctx != nil {
+		project = project.ApplyProfiles(*ctx)
+	}
+	if project.Parent.ArtifactId == "" {
+		effective := *project
+		return &effective, nil
+	}
+	if seen[project.Parent.coordinates()] {
+		return nil, fmt.Errorf("cycle detected in parent chain at %s", project.Parent.coordinates())
+	}
+	seen[project.Parent.coordinates()] = true
+
+	parent, err := pf.resolveParentFile(resolver)
+	if err != nil {
+		return nil, err
+	}
+	effectiveParent, err := parent.effectivePOM(ctx, resolver, seen)
+	if err != nil {
+		return nil, err
+	}
+	return inherit(project, effectiveParent), nil
+}
+
+// EffectiveFromFiles parse the child POM and its parent chain (direct parent first) and
+// return the child project with every parent merged into it
+func EffectiveFromFiles(childPath string, parentPaths ...string) (*MavenProject, error) {
+	child, err := Parse(childPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var parents []*MavenProject
+	for _, parentPath := range parentPaths {
+		parent, err := Parse(parentPath)
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent)
+	}
+
+	effective := child
+	if len(parents) > 0 {
+		// merge from the top-most ancestor down to the child
+		merged := parents[len(parents)-1]
+		for i := len(parents) - 2; i >= 0; i-- {
+			merged = inherit(parents[i], merged)
+		}
+		effective = inherit(child, merged)
+	}
+	return effective, nil
+}
+
+// inherit return a copy of child with the inheritable elements of parent merged into it.
+// Values declared by the child always take precedence.
+func inherit(child, parent *MavenProject) *MavenProject {
+	merged := *child
+
+	if merged.GroupId == "" {
+		merged.GroupId = parent.EffectiveGroupId()
+	}
+	if merged.Version == "" {
+		merged.Version = parent.EffectiveVersion()
+	}
+	if merged.Url == "" {
+		merged.Url = parent.Url
+	}
+	if isZero(merged.Organization) {
+		merged.Organization = parent.Organization
+	}
+	if len(merged.Licenses) == 0 {
+		merged.Licenses = parent.Licenses
+	}
+	if isZero(merged.Scm) {
+		merged.Scm = parent.Scm
+	}
+	if isZero(merged.IssueManagement) {
+		merged.IssueManagement = parent.IssueManagement
+	}
+	if isZero(merged.CiManagement) {
+		merged.CiManagement = parent.CiManagement
+	}
+	if isZero(merged.DistributionManagement) {
+		merged.DistributionManagement = parent.DistributionManagement
+	}
+
+	merged.Properties = Properties{}
+	for k, v := range parent.Properties {
+		merged.Properties[k] = v
+	}
+	for k, v := range child.Properties {
+		merged.Properties[k] = v
+	}
+
+	merged.DependencyManagement.Dependencies = mergeDependencies(child.DependencyManagement.Dependencies,
+		parent.DependencyManagement.Dependencies)
+	merged.Dependencies = mergeDependencies(child.Dependencies, parent.Dependencies)
+
+	merged.Repositories = mergeRepositories(child.Repositories, parent.Repositories)
+	merged.PluginRepositories = mergePluginRepositories(child.PluginRepositories, parent.PluginRepositories)
+
+	if merged.Build.FinalName == "" {
+		merged.Build.FinalName = parent.Build.FinalName
+	}
+	if merged.Build.SourceDirectory == "" {
+		merged.Build.SourceDirectory = parent.Build.SourceDirectory
+	}
+	if len(merged.Build.Resources) == 0 {
+		merged.Build.Resources = parent.Build.Resources
+	}
+	if len(merged.Build.TestResources) == 0 {
+		merged.Build.TestResources = parent.Build.TestResources
+	}
+	merged.Build.Plugins = mergePlugins(child.Build.Plugins, parent.Build.Plugins)
+	merged.Build.PluginManagement.Plugins = mergePlugins(child.Build.PluginManagement.Plugins,
+		parent.Build.PluginManagement.Plugins)
+
+	return &merged
+}
+
+// mergeDependencies return the child dependencies followed by the parent ones it does not override
+func mergeDependencies(child, parent []Dependency) []Dependency {
+	var merged []Dependency
+	merged = append(merged, child...)
+	for _, dep := range parent {
+		overridden := false
+		for _, c := range child {
+			if c.SameArtifact(dep) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, dep)
+		}
+	}
+	return merged
+}
+
+// mergeRepositories return the child repositories followed by the parent ones with a different id
+func mergeRepositories(child, parent []Repository) []Repository {
+	var merged []Repository
+	merged = append(merged, child...)
+	for _, repo := range parent {
+		if !containsRepository(child, repo.Id) {
+			merged = append(merged, repo)
+		}
+	}
+	return merged
+}
+
+// mergePlugins return the child plugins followed by the parent ones it does not override
+func mergePlugins(child, parent []Plugin) []Plugin {
+	var merged []Plugin
+	merged = append(merged, child...)
+	for _, plugin := range parent {
+		overridden := false
+		for _, c := range child {
+			if c.key() == plugin.key() {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, plugin)
+		}
+	}
+	return merged
+}
+
+// mergePluginRepositories return the child plugin repositories followed by the parent ones with a different id
+func mergePluginRepositories(child, parent []PluginRepository) []PluginRepository {
+	merged := append([]PluginRepository{}, child...)
+	for _, repo := range parent {
+		overridden := false
+		for _, c := range child {
+			if c.Id == repo.Id {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, repo)
+		}
+	}
+	return merged
+}
+
+func containsRepository(repos []Repository, id string) bool {
+	for _, repo := range repos {
+		if repo.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func isZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+// DirectArtifactGAVs return the groupId:artifactId:version of the direct dependencies and build
+// plugins of the project once the parent chain, the profiles active in each of its POMs, the imported BOMs, the
+// management sections and the properties are resolved. Transitive dependencies are not computed.
+// Plugins without version, which maven resolves from the repository metadata, are left out.
+func (mp *MavenProject) DirectArtifactGAVs(ctx ActivationContext, resolver ParentResolver) ([]string, error) {
+	effective, err := (&ParsedFile{Project: mp}).EffectivePOM(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := effective.ResolveImportedBOMs(resolver); err != nil {
+		return nil, err
+	}
+
+	var gavs []string
+	seen := map[string]bool{}
+	add := func(gav string) {
+		if !seen[gav] {
+			seen[gav] = true
+			gavs = append(gavs, gav)
+		}
+	}
+
+	for _, dep := range effective.ResolvedDependencies() {
+		if dep.Version == "" || strings.Contains(dep.Version, "${") {
+			return nil, fmt.Errorf("can't resolve version of dependency %s:%s", dep.GroupId, dep.ArtifactId)
+		}
+		add(dep.GroupId + ":" + dep.ArtifactId + ":" + dep.Version)
+	}
+	for _, plugin := range effective.ResolvedPlugins() {
+		if plugin.Version != "" {
+			add(plugin.EffectiveGroupId() + ":" + plugin.ArtifactId + ":" + plugin.Version)
+		}
+	}
+	return gavs, nil
+}
+
+// EffectivePOMBytes return the flattened pom.xml of the child POM: its parent chain (located at the
+// relativePath of each parent, then using resolver) and the profiles active in each POM of the chain
+// merged into it, the imported BOMs and the managed versions inlined and the placeholders resolved
+// wherever they appear. The parent and profiles sections are dropped.
+func EffectivePOMBytes(childPath string, resolver ParentResolver, ctx ActivationContext) ([]byte, error) {
+	pf, err := ParseFile(childPath)
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := pf.EffectivePOM(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := effective.ResolveImportedBOMs(resolver); err != nil {
+		return nil, err
+	}
+
+	flattened := *effective
+	flattened.GroupId = effective.Interpolate(effective.EffectiveGroupId())
+	flattened.ArtifactId = effective.Interpolate(effective.ArtifactId)
+	flattened.Version = effective.Interpolate(effective.EffectiveVersion())
+	flattened.Packaging = effective.Interpolate(effective.Packaging)
+	flattened.Parent = Parent{}
+	flattened.Profiles = nil
+
+	flattened.DependencyManagement.Dependencies = nil
+	for _, managed := range effective.DependencyManagement.Dependencies {
+		if managed.Scope != "import" {
+			flattened.DependencyManagement.Dependencies = append(flattened.DependencyManagement.Dependencies,
+				effective.resolveDependency(managed))
+		}
+	}
+	flattened.Dependencies = effective.ResolvedDependencies()
+	flattened.Build.Plugins = effective.ResolvedPlugins()
+
+	// the remaining placeholders (name, url, exclusions, pluginManagement, configuration, ...) are
+	// resolved on a copy, the effective POM sharing its lists with the parsed and resolved projects
+	flattened = deepCopy(reflect.ValueOf(flattened)).Interface().(MavenProject)
+	walkStrings(reflect.ValueOf(&flattened).Elem(), effective.Interpolate)
+
+	var buf bytes.Buffer
+	if err := writeDocument(&buf, pf.Preamble, &flattened); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
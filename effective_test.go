@@ -0,0 +1,625 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMavenProject_MinimalPOM(t *testing.T) {
+	pomStr := `
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>my-app</artifactId>
+    <packaging>war</packaging>
+    <properties>
+        <slf4j.version>1.7.22</slf4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.12</version>
+                <scope>test</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-war-plugin</artifactId>
+                <version>3.2.2</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	minimal := project.MinimalPOM()
+	if minimal.GroupId != "com.example" {
+		t.Errorf("groupId does not match (expected: com.example, found: %s)", minimal.GroupId)
+	}
+	if minimal.ArtifactId != "my-app" {
+		t.Errorf("artifactId does not match (expected: my-app, found: %s)", minimal.ArtifactId)
+	}
+	if minimal.Version != "1.0.0" {
+		t.Errorf("version does not match (expected: 1.0.0, found: %s)", minimal.Version)
+	}
+	if minimal.Packaging != "war" {
+		t.Errorf("packaging does not match (expected: war, found: %s)", minimal.Packaging)
+	}
+	if minimal.Parent.ArtifactId != "" || len(minimal.Properties) != 0 || len(minimal.Build.Plugins) != 0 ||
+		len(minimal.DependencyManagement.Dependencies) != 0 {
+		t.Errorf("expecting only coordinates and dependencies, found %+v", minimal)
+	}
+
+	if len(minimal.Dependencies) != 2 {
+		t.Fatalf("expecting 2 dependencies found %d", len(minimal.Dependencies))
+	}
+	if minimal.Dependencies[0].Version != "4.12" || minimal.Dependencies[0].Scope != "test" {
+		t.Errorf("managed dependency not resolved (expected: 4.12/test, found: %s/%s)",
+			minimal.Dependencies[0].Version, minimal.Dependencies[0].Scope)
+	}
+	if minimal.Dependencies[1].Version != "1.7.22" {
+		t.Errorf("version does not match (expected: 1.7.22, found: %s)", minimal.Dependencies[1].Version)
+	}
+}
+
+func TestEffectiveFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parentPath := writeFile(t, dir, "parent/pom.xml", `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <slf4j.version>1.7.22</slf4j.version>
+        <junit.version>4.12</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+    </dependencies>
+</project>`)
+	childPath := writeFile(t, dir, "child/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.version}</version>
+        </dependency>
+    </dependencies>
+</project>`)
+
+	project, err := EffectiveFromFiles(childPath, parentPath)
+	if err != nil {
+		t.Fatalf("unable to compute effective project. Reason: %s", err)
+	}
+
+	if project.GroupId != "com.example" {
+		t.Errorf("groupId does not match (expected: com.example, found: %s)", project.GroupId)
+	}
+	if project.Packaging != "" {
+		t.Errorf("packaging should not be inherited (found: %s)", project.Packaging)
+	}
+	if project.Properties["slf4j.version"] != "1.7.30" {
+		t.Errorf("slf4j.version does not match (expected: 1.7.30, found: %s)", project.Properties["slf4j.version"])
+	}
+	if project.Properties["junit.version"] != "4.12" {
+		t.Errorf("junit.version does not match (expected: 4.12, found: %s)", project.Properties["junit.version"])
+	}
+
+	deps := project.ResolvedDependencies()
+	if len(deps) != 2 {
+		t.Fatalf("expecting 2 dependencies found %d", len(deps))
+	}
+	if deps[0].ArtifactId != "junit" || deps[0].Version != "4.12" {
+		t.Errorf("dependency[0] does not match (expected: junit:4.12, found: %s:%s)", deps[0].ArtifactId, deps[0].Version)
+	}
+	if deps[1].ArtifactId != "slf4j-api" || deps[1].Version != "1.7.30" {
+		t.Errorf("dependency[1] does not match (expected: slf4j-api:1.7.30, found: %s:%s)", deps[1].ArtifactId, deps[1].Version)
+	}
+}
+
+func TestMavenProject_DirectArtifactGAVs(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:parent:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>${slf4j.version}</version>
+            </dependency>
+            <dependency>
+                <groupId>org.example</groupId>
+                <artifactId>bom</artifactId>
+                <version>2.0</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.1</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+    </build>
+    <profiles>
+        <profile>
+            <id>legacy</id>
+            <dependencies>
+                <dependency>
+                    <groupId>org.example</groupId>
+                    <artifactId>fromparentprofile</artifactId>
+                    <version>1.0</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`,
+		"org.example:bom:2.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>bom</artifactId>
+    <version>2.0</version>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.google.guava</groupId>
+                <artifactId>guava</artifactId>
+                <version>30.1-jre</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`,
+	}
+
+	pomStr := `
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>com.google.guava</groupId>
+            <artifactId>guava</artifactId>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles>
+        <profile>
+            <id>legacy</id>
+            <properties>
+                <slf4j.version>1.7.22</slf4j.version>
+            </properties>
+            <dependencies>
+                <dependency>
+                    <groupId>junit</groupId>
+                    <artifactId>junit</artifactId>
+                    <version>4.12</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	gavs, err := project.DirectArtifactGAVs(ActivationContext{}, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"org.slf4j:slf4j-api:1.7.30",
+		"com.google.guava:guava:30.1-jre",
+		"org.apache.maven.plugins:maven-compiler-plugin:3.8.1",
+	}
+	if !reflect.DeepEqual(gavs, expected) {
+		t.Errorf("gavs do not match (expected: %v, found: %v)", expected, gavs)
+	}
+
+	gavs, err = project.DirectArtifactGAVs(ActivationContext{ActiveProfiles: []string{"legacy"}}, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{
+		"junit:junit:4.12",
+		"org.slf4j:slf4j-api:1.7.22",
+		"com.google.guava:guava:30.1-jre",
+		"org.example:fromparentprofile:1.0",
+		"org.apache.maven.plugins:maven-compiler-plugin:3.8.1",
+	}
+	if !reflect.DeepEqual(gavs, expected) {
+		t.Errorf("gavs do not match (expected: %v, found: %v)", expected, gavs)
+	}
+}
+
+func TestEffectivePOMBytes(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:parent:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>${slf4j.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.1</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+    </build>
+</project>`,
+	}
+
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	childPath := writeFile(t, dir, "child/pom.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <packaging>jar</packaging>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles>
+        <profile>
+            <id>test</id>
+            <dependencies>
+                <dependency>
+                    <groupId>junit</groupId>
+                    <artifactId>junit</artifactId>
+                    <version>4.12</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`)
+
+	output, err := EffectivePOMBytes(childPath, resolver, ActivationContext{ActiveProfiles: []string{"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, absent := range []string{"<parent>", "<profiles>", "${slf4j.version}"} {
+		if bytes.Contains(output, []byte(absent)) {
+			t.Errorf("expecting %s not to be written, found:\n%s", absent, output)
+		}
+	}
+
+	flattened, err := ParseReader(bytes.NewReader(output))
+	if err != nil {
+		t.Fatalf("unable to parse flattened pom file. Reason: %s", err)
+	}
+	project := flattened.Project
+	if project.GroupId != "org.example" || project.ArtifactId != "child" || project.Version != "1.0" {
+		t.Errorf("coordinates does not match (expected: org.example:child:1.0, found: %s:%s:%s)",
+			project.GroupId, project.ArtifactId, project.Version)
+	}
+	if project.Packaging != "jar" {
+		t.Errorf("packaging does not match (expected: jar, found: %s)", project.Packaging)
+	}
+
+	var gavs []string
+	for _, dep := range project.Dependencies {
+		gavs = append(gavs, dep.GroupId+":"+dep.ArtifactId+":"+dep.Version)
+	}
+	expected := []string{"junit:junit:4.12", "org.slf4j:slf4j-api:1.7.30"}
+	if !reflect.DeepEqual(gavs, expected) {
+		t.Errorf("dependencies does not match (expected: %v, found: %v)", expected, gavs)
+	}
+	if len(project.Build.Plugins) != 1 || project.Build.Plugins[0].Version != "3.8.1" {
+		t.Errorf("expecting maven-compiler-plugin 3.8.1, found %v", project.Build.Plugins)
+	}
+
+	if _, err := EffectivePOMBytes(filepath.Join(dir, "missing.xml"), resolver, ActivationContext{}); err == nil {
+		t.Error("expecting an error for a missing file")
+	}
+}
+
+func TestEffectivePOMBytes_ParentChain(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:root:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <profiles>
+        <profile>
+            <id>ci</id>
+            <activation>
+                <property>
+                    <name>env.CI</name>
+                </property>
+            </activation>
+            <dependencies>
+                <dependency>
+                    <groupId>org.example</groupId>
+                    <artifactId>fromrootprofile</artifactId>
+                    <version>1.0</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`,
+	}
+
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the parent is only available on the filesystem, at the default relativePath
+	writeFile(t, dir, "pom.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>parent</artifactId>
+    <packaging>pom</packaging>
+    <profiles>
+        <profile>
+            <id>default</id>
+            <activation>
+                <activeByDefault>true</activeByDefault>
+            </activation>
+            <dependencies>
+                <dependency>
+                    <groupId>org.example</groupId>
+                    <artifactId>fromparentprofile</artifactId>
+                    <version>1.0</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`)
+	childPath := writeFile(t, dir, "child/pom.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+</project>`)
+
+	output, err := EffectivePOMBytes(childPath, resolver, ActivationContext{Properties: map[string]string{"env.CI": "true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := ParseReader(bytes.NewReader(output))
+	if err != nil {
+		t.Fatalf("unable to parse flattened pom file. Reason: %s", err)
+	}
+	var gavs []string
+	for _, dep := range flattened.Project.Dependencies {
+		gavs = append(gavs, dep.GroupId+":"+dep.ArtifactId+":"+dep.Version)
+	}
+	expected := []string{"org.example:fromparentprofile:1.0", "org.example:fromrootprofile:1.0"}
+	if !reflect.DeepEqual(gavs, expected) {
+		t.Errorf("dependencies does not match (expected: %v, found: %v)", expected, gavs)
+	}
+}
+
+func TestEffectivePOMBytes_Interpolation(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:parent:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <url>https://example.org/${project.artifactId}</url>
+    <properties>
+        <excluded.group>commons-logging</excluded.group>
+        <java.release>11</java.release>
+    </properties>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.1</version>
+                    <configuration>
+                        <release>${java.release}</release>
+                    </configuration>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+    </build>
+</project>`,
+	}
+
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	childPath := writeFile(t, dir, "child/pom.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <name>${project.artifactId} module</name>
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework</groupId>
+            <artifactId>spring-core</artifactId>
+            <version>5.3.0</version>
+            <exclusions>
+                <exclusion>
+                    <groupId>${excluded.group}</groupId>
+                    <artifactId>commons-logging</artifactId>
+                </exclusion>
+            </exclusions>
+        </dependency>
+    </dependencies>
+    <build>
+        <finalName>${project.artifactId}-${project.version}</finalName>
+    </build>
+</project>`)
+
+	output, err := EffectivePOMBytes(childPath, resolver, ActivationContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(output, []byte("${")) {
+		t.Errorf("expecting every placeholder to be resolved, found:\n%s", output)
+	}
+	for _, present := range []string{"<name>child module</name>", "<url>https://example.org/child</url>",
+		"<finalName>child-1.0</finalName>", "<groupId>commons-logging</groupId>", "<release>11</release>"} {
+		if !bytes.Contains(output, []byte(present)) {
+			t.Errorf("expecting %s to be written, found:\n%s", present, output)
+		}
+	}
+
+}
+
+// writeFile write content to dir/name, creating the missing directories, and return the file path
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,86 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint return the hex encoded SHA-256 of a canonical serialization of every field of the
+// project: the elements of the lists and the properties are sorted, and the namespaces and the
+// whitespace surrounding values are ignored. Two projects sharing a fingerprint declare the same
+// build configuration, whatever the order and the indentation of their elements.
+func (mp *MavenProject) Fingerprint() string {
+	sum := sha256.Sum256([]byte(canonicalString(reflect.ValueOf(mp))))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalString serialize v independently of the order of its lists and maps
+func canonicalString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return canonicalString(v.Elem())
+	case reflect.Struct:
+		if v.Type() == xmlNameType {
+			return strconv.Quote(v.Interface().(xml.Name).Local)
+		}
+		var sb strings.Builder
+		sb.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			fmt.Fprintf(&sb, "%s:%s;", field.Name, canonicalString(v.Field(i)))
+		}
+		sb.WriteString("}")
+		return sb.String()
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elements[i] = canonicalString(v.Index(i))
+		}
+		sort.Strings(elements)
+		return "[" + strings.Join(elements, ",") + "]"
+	case reflect.Map:
+		entries := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			entries = append(entries, canonicalString(key)+"="+canonicalString(v.MapIndex(key)))
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ",") + "}"
+	case reflect.String:
+		return strconv.Quote(strings.TrimSpace(v.String()))
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,113 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestMavenProject_Fingerprint(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.0.0</version>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+        <junit.version>4.13</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.version}</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <release>11</release>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+	reorderedStr := `
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <artifactId>my-app</artifactId>
+  <groupId>com.example</groupId>
+  <version>1.0.0</version>
+  <build><plugins><plugin><configuration><release>11</release></configuration><artifactId>maven-compiler-plugin</artifactId></plugin></plugins></build>
+  <dependencies>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><scope>test</scope><version>${junit.version}</version></dependency>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>${slf4j.version}</version></dependency>
+  </dependencies>
+  <properties><junit.version>4.13</junit.version><slf4j.version>1.7.30</slf4j.version></properties>
+</project>`
+
+	var project, reordered MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+	if err := xml.Unmarshal([]byte(reorderedStr), &reordered); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	fingerprint := project.Fingerprint()
+	if len(fingerprint) != 64 {
+		t.Errorf("expecting a hex encoded SHA-256, found %s", fingerprint)
+	}
+	if fingerprint != project.Fingerprint() {
+		t.Error("expecting the fingerprint to be stable")
+	}
+	if reordered.Fingerprint() != fingerprint {
+		t.Errorf("fingerprint of reordered project does not match (expected: %s, found: %s)", fingerprint, reordered.Fingerprint())
+	}
+
+	changes := map[string]func(mp *MavenProject){
+		"version":       func(mp *MavenProject) { mp.Version = "1.0.1" },
+		"property":      func(mp *MavenProject) { mp.Properties["junit.version"] = "4.12" },
+		"scope":         func(mp *MavenProject) { mp.Dependencies[1].Scope = "compile" },
+		"configuration": func(mp *MavenProject) { mp.Build.Plugins[0].Configuration.Children[0].Value = "17" },
+		"relativePath":  func(mp *MavenProject) { mp.Parent.RelativePath = new(string) },
+	}
+	for name, change := range changes {
+		var changed MavenProject
+		if err := xml.Unmarshal([]byte(pomStr), &changed); err != nil {
+			t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+		}
+		change(&changed)
+		if changed.Fingerprint() == fingerprint {
+			t.Errorf("expecting the fingerprint to change with the %s", name)
+		}
+	}
+}
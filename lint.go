@@ -0,0 +1,557 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Represent a refactoring suggestion: a literal version repeated across several artifacts
+// that could be extracted into a property
+type Suggestion struct {
+	Version   string
+	Property  string
+	Artifacts []string
+}
+
+// VersionPropertySuggestions propose to extract literal versions used 2+ times into properties
+func (mp *MavenProject) VersionPropertySuggestions() []Suggestion {
+	var order []string
+	artifacts := map[string][]string{}
+	groups := map[string]map[string]bool{}
+
+	add := func(groupId, artifactId, version string) {
+		if version == "" || strings.Contains(version, "${") {
+			return
+		}
+		if _, exist := artifacts[version]; !exist {
+			order = append(order, version)
+			groups[version] = map[string]bool{}
+		}
+		artifacts[version] = append(artifacts[version], groupId+":"+artifactId)
+		groups[version][groupId] = true
+	}
+
+	for _, dep := range mp.DependencyManagement.Dependencies {
+		add(dep.GroupId, dep.ArtifactId, dep.Version)
+	}
+	for _, dep := range mp.Dependencies {
+		add(dep.GroupId, dep.ArtifactId, dep.Version)
+	}
+	for _, plugin := range mp.Build.Plugins {
+		add(plugin.GroupId, plugin.ArtifactId, plugin.Version)
+	}
+
+	var suggestions []Suggestion
+	for _, version := range order {
+		if len(artifacts[version]) < 2 {
+			continue
+		}
+
+		// name the property after the shared groupId when there is one
+		name := strings.SplitN(artifacts[version][0], ":", 2)[1]
+		if len(groups[version]) == 1 {
+			name = strings.SplitN(artifacts[version][0], ":", 2)[0]
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Version:   version,
+			Property:  name + ".version",
+			Artifacts: artifacts[version],
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Version < suggestions[j].Version
+	})
+	return suggestions
+}
+
+// AllURLs return every distinct URL referenced by the project, in declaration order
+func (mp *MavenProject) AllURLs() []string {
+	var urls []string
+	seen := map[string]bool{}
+	add := func(url string) {
+		url = strings.TrimSpace(url)
+		if url != "" && !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+
+	add(mp.Url)
+	add(mp.Organization.Url)
+	for _, license := range mp.Licenses {
+		add(license.Url)
+	}
+	add(mp.Scm.Url)
+	add(mp.Scm.Connection)
+	add(mp.Scm.DeveloperConnection)
+	add(mp.IssueManagement.Url)
+	add(mp.CiManagement.Url)
+	for _, repo := range mp.Repositories {
+		add(repo.Url)
+	}
+	for _, repo := range mp.PluginRepositories {
+		add(repo.Url)
+	}
+	add(mp.DistributionManagement.Repository.Url)
+	add(mp.DistributionManagement.SnapshotRepository.Url)
+	add(mp.DistributionManagement.Site.Url)
+	add(mp.DistributionManagement.DownloadUrl)
+
+	return urls
+}
+
+// LintEmptyDependencies warn when a jar project has no dependencies at all, or when
+// dependencyManagement entries are not used by any dependency of the project
+func (mp *MavenProject) LintEmptyDependencies() []string {
+	var warnings []string
+
+	packaging := mp.Packaging
+	if packaging == "" {
+		packaging = "jar"
+	}
+	if packaging == "jar" && len(mp.Dependencies) == 0 {
+		warnings = append(warnings, "jar packaged project has no dependencies")
+	}
+
+	for _, managed := range mp.UnusedManagedDependencies() {
+		warnings = append(warnings, fmt.Sprintf("managed dependency %s:%s is not used", managed.GroupId, managed.ArtifactId))
+	}
+
+	return warnings
+}
+
+// UnusedManagedDependencies return the dependencyManagement entries matching no dependency of the
+// project or of its profiles. A parent POM usually manages versions for its modules: pass them as
+// modules so the entries they use are not reported.
+func (mp *MavenProject) UnusedManagedDependencies(modules ...*MavenProject) []Dependency {
+	projects := append([]*MavenProject{mp}, modules...)
+
+	var unused []Dependency
+	for _, managed := range mp.DependencyManagement.Dependencies {
+		// imported BOMs are not meant to be used directly
+		if managed.Scope == "import" {
+			continue
+		}
+
+		used := false
+		for _, project := range projects {
+			if project.usesDependency(managed) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			unused = append(unused, managed)
+		}
+	}
+	return unused
+}
+
+// usesDependency return true if the project or one of its profiles declare a dependency on the same artifact
+func (mp *MavenProject) usesDependency(managed Dependency) bool {
+	for _, dep := range mp.Dependencies {
+		if dep.SameArtifact(managed) {
+			return true
+		}
+	}
+	for _, profile := range mp.Profiles {
+		for _, dep := range profile.Dependencies {
+			if dep.SameArtifact(managed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Represent an artifact declared with different scopes across the sections of the project
+type ScopeConflict struct {
+	GroupId    string
+	ArtifactId string
+	// The scope declared, keyed by section (main, dependencyManagement or profile:<id>)
+	Scopes map[string]string
+}
+
+// ScopeConflicts report the artifacts declared with differing scopes across the main
+// dependencies, the dependencyManagement and the profiles
+func (mp *MavenProject) ScopeConflicts() []ScopeConflict {
+	var keys []string
+	declared := map[string]Dependency{}
+	scopes := map[string]map[string]string{}
+
+	add := func(section string, deps []Dependency, explicitOnly bool) {
+		for _, dep := range deps {
+			if explicitOnly && dep.Scope == "" {
+				continue
+			}
+			key := dep.key()
+			if _, exist := scopes[key]; !exist {
+				keys = append(keys, key)
+				declared[key] = dep
+				scopes[key] = map[string]string{}
+			}
+			scopes[key][section] = dep.EffectiveScope()
+		}
+	}
+
+	add("main", mp.Dependencies, false)
+	add("dependencyManagement", mp.DependencyManagement.Dependencies, true)
+	for _, profile := range mp.Profiles {
+		add("profile:"+profile.Id, profile.Dependencies, false)
+	}
+
+	var conflicts []ScopeConflict
+	for _, key := range keys {
+		distinct := map[string]bool{}
+		for _, scope := range scopes[key] {
+			distinct[scope] = true
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, ScopeConflict{
+			GroupId:    declared[key].GroupId,
+			ArtifactId: declared[key].ArtifactId,
+			Scopes:     scopes[key],
+		})
+	}
+	return conflicts
+}
+
+// Represent a dependency declaring no scope whose effective scope is supplied by the dependencyManagement
+type ScopeChange struct {
+	GroupId    string
+	ArtifactId string
+	// The managed scope in effect instead of the default compile scope
+	ManagedScope string
+}
+
+// ScopeChangesFromManagement report the dependencies declaring no scope whose effective scope comes
+// from the dependencyManagement, a subtle source of scope changes since the default would be compile
+func (mp *MavenProject) ScopeChangesFromManagement() []ScopeChange {
+	var changes []ScopeChange
+	for _, dep := range mp.Dependencies {
+		if dep.Scope != "" {
+			continue
+		}
+		if scope := mp.Interpolate(mp.ApplyDependencyManagement(dep).Scope); scope != "" {
+			changes = append(changes, ScopeChange{
+				GroupId:      mp.Interpolate(dep.GroupId),
+				ArtifactId:   mp.Interpolate(dep.ArtifactId),
+				ManagedScope: scope,
+			})
+		}
+	}
+	return changes
+}
+
+// ValidatePinned return an error for each resolved dependency whose version is missing, a range,
+// a snapshot or an unresolved placeholder, enforcing a strict reproducibility policy
+func (mp *MavenProject) ValidatePinned() []error {
+	var errs []error
+	for _, dep := range mp.ResolvedDependencies() {
+		name := dep.GroupId + ":" + dep.ArtifactId
+		switch {
+		case dep.Version == "":
+			errs = append(errs, fmt.Errorf("dependency %s has no version", name))
+		case strings.Contains(dep.Version, "${"):
+			errs = append(errs, fmt.Errorf("dependency %s version %s contains an unresolved placeholder", name, dep.Version))
+		case strings.ContainsAny(dep.Version, "[]()"):
+			errs = append(errs, fmt.Errorf("dependency %s version %s is a range", name, dep.Version))
+		case IsSnapshot(dep.Version):
+			errs = append(errs, fmt.Errorf("dependency %s version %s is a snapshot", name, dep.Version))
+		}
+	}
+	return errs
+}
+
+// Represent a dependency whose version is overridden by a profile
+type ProfileOverride struct {
+	GroupId        string
+	ArtifactId     string
+	BaseVersion    string
+	ProfileId      string
+	ProfileVersion string
+}
+
+// ProfileVersionOverrides report the dependencies declared by both the base dependencies and a
+// profile with a different version, causing a version drift when the profile is activated
+func (mp *MavenProject) ProfileVersionOverrides() []ProfileOverride {
+	var overrides []ProfileOverride
+	base := mp.ResolvedDependencies()
+	for _, profile := range mp.Profiles {
+		for _, dep := range profile.Dependencies {
+			dep = mp.resolveDependency(dep)
+			if dep.Version == "" {
+				continue
+			}
+			for _, baseDep := range base {
+				if baseDep.SameArtifact(dep) && baseDep.Version != dep.Version {
+					overrides = append(overrides, ProfileOverride{
+						GroupId:        dep.GroupId,
+						ArtifactId:     dep.ArtifactId,
+						BaseVersion:    baseDep.Version,
+						ProfileId:      profile.Id,
+						ProfileVersion: dep.Version,
+					})
+				}
+			}
+		}
+	}
+	return overrides
+}
+
+// ReservedPropertyPrefixes list the prefixes of the properties maven builds from the model, the
+// environment and the settings
+var ReservedPropertyPrefixes = []string{"project.", "pom.", "env.", "settings."}
+
+// conventionalProperties are reserved looking properties that maven plugins expect to be user defined
+var conventionalProperties = map[string]bool{
+	"project.build.sourceEncoding":     true,
+	"project.reporting.outputEncoding": true,
+	"project.build.outputTimestamp":    true,
+}
+
+// ShadowingProperties return, sorted, the keys of the properties colliding with a reserved prefix
+// (e.g. a user defined project.version). The conventional project.build.sourceEncoding,
+// project.reporting.outputEncoding and project.build.outputTimestamp are not reported.
+func (mp *MavenProject) ShadowingProperties() []string {
+	var keys []string
+	for key := range mp.Properties {
+		if conventionalProperties[key] {
+			continue
+		}
+		for _, prefix := range ReservedPropertyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RedundantInheritedCoordinates return the coordinates (groupId, version) the project declares with
+// the same value as its parent, which could be inherited instead
+func (mp *MavenProject) RedundantInheritedCoordinates() []string {
+	if mp.Parent.ArtifactId == "" {
+		return nil
+	}
+
+	var redundant []string
+	if mp.GroupId != "" && mp.GroupId == mp.Parent.GroupId {
+		redundant = append(redundant, "groupId")
+	}
+	if mp.Version != "" && mp.Version == mp.Parent.Version {
+		redundant = append(redundant, "version")
+	}
+	return redundant
+}
+
+// MisplacedArtifacts return the groupId:artifactId declared both as a build plugin and as a
+// dependency, which usually means one of them was added to the wrong section
+func (mp *MavenProject) MisplacedArtifacts() []string {
+	plugins := map[string]bool{}
+	for _, plugin := range mp.ResolvedPlugins() {
+		plugins[plugin.key()] = true
+	}
+
+	var misplaced []string
+	seen := map[string]bool{}
+	for _, dep := range mp.ResolvedDependencies() {
+		key := dep.GroupId + ":" + dep.ArtifactId
+		if plugins[key] && !seen[key] {
+			seen[key] = true
+			misplaced = append(misplaced, key)
+		}
+	}
+	return misplaced
+}
+
+// DependenciesWithoutLicense return the resolved dependencies whose POM, fetched using resolver,
+// declares no license either directly or through its parent chain
+func (mp *MavenProject) DependenciesWithoutLicense(resolver ParentResolver) ([]Dependency, error) {
+	var deps []Dependency
+	for _, dep := range mp.ResolvedDependencies() {
+		pom, err := resolvePOM(resolver, dep.GroupId, dep.ArtifactId, dep.Version)
+		if err != nil {
+			return nil, fmt.Errorf("can't resolve dependency %s:%s:%s, %v", dep.GroupId, dep.ArtifactId, dep.Version, err)
+		}
+		if pom, err = pom.EffectivePOM(resolver); err != nil {
+			return nil, fmt.Errorf("can't resolve dependency %s:%s:%s, %v", dep.GroupId, dep.ArtifactId, dep.Version, err)
+		}
+		if len(pom.Licenses) == 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+// RedundantExplicitVersions return the dependencies declaring the same version as the one the
+// dependencyManagement would supply, which could be removed
+func (mp *MavenProject) RedundantExplicitVersions() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.Dependencies {
+		if dep.Version == "" {
+			continue
+		}
+		if managed := mp.managedVersion(dep); managed != "" && managed == mp.Interpolate(dep.Version) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// ManagedDowngrades return the dependencies explicitly pinned to a version lower (per CompareVersions)
+// than the one the dependencyManagement would supply, often an unintentional downgrade
+func (mp *MavenProject) ManagedDowngrades() []Dependency {
+	var deps []Dependency
+	for _, dep := range mp.Dependencies {
+		if dep.Version == "" {
+			continue
+		}
+		if managed := mp.managedVersion(dep); managed != "" && CompareVersions(mp.Interpolate(dep.Version), managed) < 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// managedVersion return the interpolated version the dependencyManagement supply for dep, if any
+func (mp *MavenProject) managedVersion(dep Dependency) string {
+	unversioned := dep
+	unversioned.Version = ""
+	return mp.Interpolate(mp.ApplyDependencyManagement(unversioned).Version)
+}
+
+// Represent an artifact declared several times with differing versions, the one winning on the
+// classpath depending on the declaration order
+type ShadowIssue struct {
+	GroupId    string
+	ArtifactId string
+	// The distinct versions declared, in declaration order
+	Versions []string
+}
+
+// ShadowedDependencies report the artifacts declared with differing versions across the base
+// dependencies and the dependencies of the profiles, dependencyManagement and properties applied
+func (mp *MavenProject) ShadowedDependencies() []ShadowIssue {
+	deps := mp.ResolvedDependencies()
+	for _, profile := range mp.Profiles {
+		for _, dep := range profile.Dependencies {
+			deps = append(deps, mp.resolveDependency(dep))
+		}
+	}
+
+	var keys []string
+	declared := map[string]Dependency{}
+	versions := map[string][]string{}
+	for _, dep := range deps {
+		key := dep.key()
+		if _, exist := declared[key]; !exist {
+			keys = append(keys, key)
+			declared[key] = dep
+		}
+		if dep.Version != "" && !contains(versions[key], dep.Version) {
+			versions[key] = append(versions[key], dep.Version)
+		}
+	}
+
+	var issues []ShadowIssue
+	for _, key := range keys {
+		if len(versions[key]) > 1 {
+			issues = append(issues, ShadowIssue{
+				GroupId:    declared[key].GroupId,
+				ArtifactId: declared[key].ArtifactId,
+				Versions:   versions[key],
+			})
+		}
+	}
+	return issues
+}
+
+// Represent an exclusion declared by several dependencies, which could be consolidated
+// (e.g. into a single dependencyManagement exclusion)
+type ExclusionGroup struct {
+	Exclusion Exclusion
+	// The dependencies declaring the exclusion, in declaration order
+	Dependencies []Dependency
+}
+
+// ConsolidatableExclusions report the exclusions declared by more than one dependency of the project,
+// in order of first declaration
+func (mp *MavenProject) ConsolidatableExclusions() []ExclusionGroup {
+	var keys []string
+	groups := map[string]*ExclusionGroup{}
+	for _, dep := range mp.Dependencies {
+		for _, exclusion := range dep.Exclusions {
+			key := exclusion.GroupId + ":" + exclusion.ArtifactId
+			group, exist := groups[key]
+			if !exist {
+				keys = append(keys, key)
+				group = &ExclusionGroup{Exclusion: exclusion}
+				groups[key] = group
+			}
+			// a dependency repeating the exclusion is counted once
+			if len(group.Dependencies) == 0 || !group.Dependencies[len(group.Dependencies)-1].SameArtifact(dep) {
+				group.Dependencies = append(group.Dependencies, dep)
+			}
+		}
+	}
+
+	var consolidatable []ExclusionGroup
+	for _, key := range keys {
+		if len(groups[key].Dependencies) > 1 {
+			consolidatable = append(consolidatable, *groups[key])
+		}
+	}
+	return consolidatable
+}
+
+// PlaceholderValues list the values, compared case insensitively, left by project generators
+// for the user to fill in
+var PlaceholderValues = []string{"todo", "fixme", "changeme", "change-me", "replace-me"}
+
+// IsTemplate return true if the project coordinates are left unresolved: a ${} placeholder remaining
+// once the properties are interpolated (parent ones included if the effective POM is given) or one
+// of the PlaceholderValues. This typically flag the output of a broken generator.
+func (mp *MavenProject) IsTemplate() bool {
+	for _, coordinate := range []string{mp.EffectiveGroupId(), mp.ArtifactId, mp.EffectiveVersion()} {
+		value := strings.TrimSpace(mp.Interpolate(coordinate))
+		if strings.Contains(value, "${") {
+			return true
+		}
+		for _, placeholder := range PlaceholderValues {
+			if strings.EqualFold(value, placeholder) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,716 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMavenProject_VersionPropertySuggestions(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencies>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-databind</artifactId>
+            <version>2.10.0</version>
+        </dependency>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-annotations</artifactId>
+            <version>2.10.0</version>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	suggestions := project.VersionPropertySuggestions()
+	if len(suggestions) != 1 {
+		t.Fatalf("expecting 1 suggestion found %d", len(suggestions))
+	}
+	if suggestions[0].Version != "2.10.0" {
+		t.Errorf("version does not match (expected: 2.10.0, found: %s)", suggestions[0].Version)
+	}
+	if suggestions[0].Property != "com.fasterxml.jackson.core.version" {
+		t.Errorf("property does not match (expected: com.fasterxml.jackson.core.version, found: %s)", suggestions[0].Property)
+	}
+	if len(suggestions[0].Artifacts) != 2 {
+		t.Errorf("expecting 2 artifacts found %d", len(suggestions[0].Artifacts))
+	}
+}
+
+func TestMavenProject_AllURLs(t *testing.T) {
+	pomStr := `
+<project>
+    <url>https://example.com/my-app</url>
+    <licenses>
+        <license>
+            <name>MIT</name>
+            <url>https://opensource.org/licenses/MIT</url>
+        </license>
+    </licenses>
+    <scm>
+        <connection>scm:git:https://github.com/example/my-app.git</connection>
+        <url>https://github.com/example/my-app</url>
+    </scm>
+    <issueManagement>
+        <system>GitHub</system>
+        <url>https://github.com/example/my-app/issues</url>
+    </issueManagement>
+    <repositories>
+        <repository>
+            <id>private-repository</id>
+            <url>http://localhost:8081/repository/maven-private/</url>
+        </repository>
+    </repositories>
+    <pluginRepositories>
+        <pluginRepository>
+            <id>private-plugin-repository</id>
+            <url>http://localhost:8081/repository/maven-private/</url>
+        </pluginRepository>
+    </pluginRepositories>
+    <distributionManagement>
+        <repository>
+            <id>releases</id>
+            <url>https://repo.example.com/releases</url>
+        </repository>
+    </distributionManagement>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	urls := project.AllURLs()
+	expected := []string{
+		"https://example.com/my-app",
+		"https://opensource.org/licenses/MIT",
+		"https://github.com/example/my-app",
+		"scm:git:https://github.com/example/my-app.git",
+		"https://github.com/example/my-app/issues",
+		"http://localhost:8081/repository/maven-private/",
+		"https://repo.example.com/releases",
+	}
+	if len(urls) != len(expected) {
+		t.Fatalf("expecting %d urls found %d (%v)", len(expected), len(urls), urls)
+	}
+	for i, url := range expected {
+		if urls[i] != url {
+			t.Errorf("url[%d] does not match (expected: %s, found: %s)", i, url, urls[i])
+		}
+	}
+}
+
+func TestMavenProject_LintEmptyDependencies(t *testing.T) {
+	project := MavenProject{ArtifactId: "my-app"}
+	warnings := project.LintEmptyDependencies()
+	if len(warnings) != 1 || warnings[0] != "jar packaged project has no dependencies" {
+		t.Errorf("expecting empty dependencies warning, found %v", warnings)
+	}
+
+	project = MavenProject{
+		ArtifactId: "my-app",
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.22"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "junit", ArtifactId: "junit"},
+		},
+	}
+	warnings = project.LintEmptyDependencies()
+	if len(warnings) != 1 || warnings[0] != "managed dependency org.slf4j:slf4j-api is not used" {
+		t.Errorf("expecting unused managed dependency warning, found %v", warnings)
+	}
+
+	project.Packaging = "pom"
+	project.Dependencies = nil
+	project.DependencyManagement.Dependencies = nil
+	if warnings := project.LintEmptyDependencies(); len(warnings) != 0 {
+		t.Errorf("expecting no warning for pom packaging, found %v", warnings)
+	}
+}
+
+func TestMavenProject_UnusedManagedDependencies(t *testing.T) {
+	parent := MavenProject{
+		ArtifactId: "parent",
+		Packaging:  "pom",
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "junit", ArtifactId: "junit", Scope: "test"},
+		},
+	}
+
+	unused := parent.UnusedManagedDependencies()
+	if len(unused) != 1 || unused[0].ArtifactId != "slf4j-api" {
+		t.Errorf("unused managed dependencies do not match (expected: [slf4j-api], found: %v)", unused)
+	}
+
+	module := &MavenProject{
+		ArtifactId: "module",
+		Profiles: []Profile{
+			{Id: "logging", Dependencies: []Dependency{{GroupId: "org.slf4j", ArtifactId: "slf4j-api"}}},
+		},
+	}
+	if unused := parent.UnusedManagedDependencies(module); len(unused) != 0 {
+		t.Errorf("expecting managed dependencies to be used by the module, found %v", unused)
+	}
+}
+
+func TestMavenProject_ScopeConflicts(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+    <profiles>
+        <profile>
+            <id>testing</id>
+            <dependencies>
+                <dependency>
+                    <groupId>org.slf4j</groupId>
+                    <artifactId>slf4j-api</artifactId>
+                    <scope>test</scope>
+                </dependency>
+                <dependency>
+                    <groupId>junit</groupId>
+                    <artifactId>junit</artifactId>
+                    <scope>test</scope>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	conflicts := project.ScopeConflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("expecting 1 scope conflict found %d", len(conflicts))
+	}
+	if conflicts[0].ArtifactId != "slf4j-api" {
+		t.Errorf("artifactId does not match (expected: slf4j-api, found: %s)", conflicts[0].ArtifactId)
+	}
+	if conflicts[0].Scopes["main"] != "compile" {
+		t.Errorf("main scope does not match (expected: compile, found: %s)", conflicts[0].Scopes["main"])
+	}
+	if conflicts[0].Scopes["profile:testing"] != "test" {
+		t.Errorf("profile scope does not match (expected: test, found: %s)", conflicts[0].Scopes["profile:testing"])
+	}
+}
+
+func TestMavenProject_ScopeChangesFromManagement(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.13</version>
+                <scope>test</scope>
+            </dependency>
+            <dependency>
+                <groupId>javax.servlet</groupId>
+                <artifactId>servlet-api</artifactId>
+                <version>2.5</version>
+                <scope>provided</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>javax.servlet</groupId>
+            <artifactId>servlet-api</artifactId>
+            <scope>compile</scope>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.30</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	expected := []ScopeChange{{GroupId: "junit", ArtifactId: "junit", ManagedScope: "test"}}
+	if changes := project.ScopeChangesFromManagement(); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("scope changes does not match (expected: %v, found: %v)", expected, changes)
+	}
+}
+
+func TestMavenProject_ValidatePinned(t *testing.T) {
+	project := MavenProject{
+		Properties: Properties{"slf4j.version": "1.7.22"},
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "${slf4j.version}"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+		},
+	}
+	if errs := project.ValidatePinned(); len(errs) != 0 {
+		t.Errorf("expecting no error found %v", errs)
+	}
+
+	tests := map[string]string{
+		"[1.0,2.0)":                 "dependency com.example:lib version [1.0,2.0) is a range",
+		"1.0-SNAPSHOT":              "dependency com.example:lib version 1.0-SNAPSHOT is a snapshot",
+		"${lib.version}":            "dependency com.example:lib version ${lib.version} contains an unresolved placeholder",
+		"${project.parent.version}": "dependency com.example:lib version ${project.parent.version} contains an unresolved placeholder",
+		"":                          "dependency com.example:lib has no version",
+	}
+	for version, expected := range tests {
+		project := MavenProject{
+			Dependencies: []Dependency{{GroupId: "com.example", ArtifactId: "lib", Version: version}},
+		}
+		errs := project.ValidatePinned()
+		if len(errs) != 1 || errs[0].Error() != expected {
+			t.Errorf("expecting error %s, found %v", expected, errs)
+		}
+	}
+}
+
+func TestMavenProject_ProfileVersionOverrides(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <jackson.version>2.10.0</jackson.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-databind</artifactId>
+            <version>${jackson.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+        </dependency>
+    </dependencies>
+    <profiles>
+        <profile>
+            <id>legacy</id>
+            <dependencies>
+                <dependency>
+                    <groupId>com.fasterxml.jackson.core</groupId>
+                    <artifactId>jackson-databind</artifactId>
+                    <version>2.9.10</version>
+                </dependency>
+                <dependency>
+                    <groupId>junit</groupId>
+                    <artifactId>junit</artifactId>
+                    <version>4.12</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	overrides := project.ProfileVersionOverrides()
+	if len(overrides) != 1 {
+		t.Fatalf("expecting 1 override found %d", len(overrides))
+	}
+	expected := ProfileOverride{
+		GroupId:        "com.fasterxml.jackson.core",
+		ArtifactId:     "jackson-databind",
+		BaseVersion:    "2.10.0",
+		ProfileId:      "legacy",
+		ProfileVersion: "2.9.10",
+	}
+	if overrides[0] != expected {
+		t.Errorf("override does not match (expected: %+v, found: %+v)", expected, overrides[0])
+	}
+}
+
+func TestMavenProject_ShadowingProperties(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>org.example</groupId>
+    <properties>
+        <project.groupId>com.example</project.groupId>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+        <env.HOME>/tmp</env.HOME>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	keys := project.ShadowingProperties()
+	if len(keys) != 2 {
+		t.Fatalf("expecting 2 shadowing properties found %d", len(keys))
+	}
+	if keys[0] != "env.HOME" {
+		t.Errorf("property does not match (expected: env.HOME, found: %s)", keys[0])
+	}
+	if keys[1] != "project.groupId" {
+		t.Errorf("property does not match (expected: project.groupId, found: %s)", keys[1])
+	}
+}
+
+func TestMavenProject_RedundantInheritedCoordinates(t *testing.T) {
+	pomStr := `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.1.0</version>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	redundant := project.RedundantInheritedCoordinates()
+	if len(redundant) != 1 || redundant[0] != "groupId" {
+		t.Errorf("redundant coordinates do not match (expected: [groupId], found: %v)", redundant)
+	}
+
+	project.Version = "1.0.0"
+	if redundant := project.RedundantInheritedCoordinates(); len(redundant) != 2 {
+		t.Errorf("expecting 2 redundant coordinates found %v", redundant)
+	}
+}
+
+func TestMavenProject_MisplacedArtifacts(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencies>
+        <dependency>
+            <groupId>org.apache.maven.plugins</groupId>
+            <artifactId>maven-compiler-plugin</artifactId>
+            <version>3.8.1</version>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.30</version>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.8.1</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	misplaced := project.MisplacedArtifacts()
+	if len(misplaced) != 1 || misplaced[0] != "org.apache.maven.plugins:maven-compiler-plugin" {
+		t.Errorf("misplaced artifacts do not match (expected: [org.apache.maven.plugins:maven-compiler-plugin], found: %v)", misplaced)
+	}
+}
+
+func TestMavenProject_DependenciesWithoutLicense(t *testing.T) {
+	resolver := mapResolver{
+		"org.example:parent:1.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <licenses>
+        <license>
+            <name>Apache License, Version 2.0</name>
+        </license>
+    </licenses>
+</project>`,
+		"org.example:licensed:1.0": `
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>licensed</artifactId>
+</project>`,
+		"org.example:unlicensed:2.0": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>unlicensed</artifactId>
+    <version>2.0</version>
+</project>`,
+	}
+
+	project := MavenProject{Dependencies: []Dependency{
+		{GroupId: "org.example", ArtifactId: "licensed", Version: "1.0"},
+		{GroupId: "org.example", ArtifactId: "unlicensed", Version: "2.0"},
+	}}
+
+	deps, err := project.DependenciesWithoutLicense(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].ArtifactId != "unlicensed" {
+		t.Errorf("dependencies without license do not match (expected: [unlicensed], found: %v)", deps)
+	}
+
+	project.Dependencies = append(project.Dependencies, Dependency{GroupId: "org.example", ArtifactId: "missing", Version: "1.0"})
+	if _, err := project.DependenciesWithoutLicense(resolver); err == nil {
+		t.Error("expecting an error for an unresolvable dependency")
+	}
+}
+
+func TestMavenProject_RedundantExplicitVersions(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>${slf4j.version}</version>
+            </dependency>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.12</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.30</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.13</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	deps := project.RedundantExplicitVersions()
+	if len(deps) != 1 || deps[0].ArtifactId != "slf4j-api" {
+		t.Errorf("redundant versions do not match (expected: [slf4j-api], found: %v)", deps)
+	}
+}
+
+func TestMavenProject_ShadowedDependencies(t *testing.T) {
+	project := MavenProject{
+		Dependencies: []Dependency{
+			{GroupId: "com.google.guava", ArtifactId: "guava", Version: "30.1-jre"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+		},
+		Profiles: []Profile{
+			{Id: "legacy", Dependencies: []Dependency{
+				{GroupId: "com.google.guava", ArtifactId: "guava", Version: "20.0"},
+				{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+			}},
+		},
+	}
+
+	issues := project.ShadowedDependencies()
+	if len(issues) != 1 {
+		t.Fatalf("expecting 1 issue found %d", len(issues))
+	}
+	if issues[0].ArtifactId != "guava" {
+		t.Errorf("artifactId does not match (expected: guava, found: %s)", issues[0].ArtifactId)
+	}
+	expected := []string{"30.1-jre", "20.0"}
+	if !reflect.DeepEqual(issues[0].Versions, expected) {
+		t.Errorf("versions do not match (expected: %v, found: %v)", expected, issues[0].Versions)
+	}
+}
+
+func TestMavenProject_ConsolidatableExclusions(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencies>
+        <dependency>
+            <groupId>org.apache.hadoop</groupId>
+            <artifactId>hadoop-common</artifactId>
+            <exclusions>
+                <exclusion>
+                    <groupId>log4j</groupId>
+                    <artifactId>log4j</artifactId>
+                </exclusion>
+                <exclusion>
+                    <groupId>commons-logging</groupId>
+                    <artifactId>commons-logging</artifactId>
+                </exclusion>
+            </exclusions>
+        </dependency>
+        <dependency>
+            <groupId>org.apache.zookeeper</groupId>
+            <artifactId>zookeeper</artifactId>
+            <exclusions>
+                <exclusion>
+                    <groupId>log4j</groupId>
+                    <artifactId>log4j</artifactId>
+                </exclusion>
+            </exclusions>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	groups := project.ConsolidatableExclusions()
+	if len(groups) != 1 {
+		t.Fatalf("expecting 1 exclusion group found %d", len(groups))
+	}
+	if exclusion := groups[0].Exclusion; exclusion.GroupId != "log4j" || exclusion.ArtifactId != "log4j" {
+		t.Errorf("exclusion does not match (expected: log4j:log4j, found: %s:%s)", exclusion.GroupId, exclusion.ArtifactId)
+	}
+	var artifactIds []string
+	for _, dep := range groups[0].Dependencies {
+		artifactIds = append(artifactIds, dep.ArtifactId)
+	}
+	expected := []string{"hadoop-common", "zookeeper"}
+	if !reflect.DeepEqual(artifactIds, expected) {
+		t.Errorf("dependencies do not match (expected: %v, found: %v)", expected, artifactIds)
+	}
+}
+
+func TestMavenProject_IsTemplate(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>${groupId}</groupId>
+    <artifactId>TODO</artifactId>
+    <version>${version}</version>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+	if !project.IsTemplate() {
+		t.Error("expecting placeholder project to be a template")
+	}
+
+	project = MavenProject{GroupId: "com.example", ArtifactId: "changeMe", Version: "1.0"}
+	if !project.IsTemplate() {
+		t.Error("expecting project with artifactId changeMe to be a template")
+	}
+
+	project = MavenProject{
+		GroupId:    "com.example",
+		ArtifactId: "my-app",
+		Version:    "${revision}",
+		Properties: Properties{"revision": "1.0.0"},
+	}
+	if project.IsTemplate() {
+		t.Error("expecting real project not to be a template")
+	}
+}
+
+func TestMavenProject_ManagedDowngrades(t *testing.T) {
+	project := MavenProject{
+		Properties: Properties{"jackson.version": "2.12.3"},
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind", Version: "${jackson.version}"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind", Version: "2.9.10"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.13"},
+			{GroupId: "com.google.guava", ArtifactId: "guava", Version: "20.0"},
+		},
+	}
+
+	deps := project.ManagedDowngrades()
+	if len(deps) != 1 {
+		t.Fatalf("expecting 1 downgrade found %d", len(deps))
+	}
+	if deps[0].ArtifactId != "jackson-databind" {
+		t.Errorf("artifactId does not match (expected: jackson-databind, found: %s)", deps[0].ArtifactId)
+	}
+}
@@ -0,0 +1,206 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ParentResolver locate the POM of an artifact from its coordinates
+type ParentResolver interface {
+	Resolve(groupId, artifactId, version string) (*MavenProject, error)
+}
+
+// EffectiveGroupId return the groupId of the project, inherited from the parent if not declared
+func (mp *MavenProject) EffectiveGroupId() string {
+	if mp.GroupId != "" {
+		return mp.GroupId
+	}
+	return mp.Parent.GroupId
+}
+
+// GroupIdMatchesParent return whether the effective groupId of the project equals the groupId of its
+// parent, properties interpolated, and whether the project has a parent at all
+func (mp *MavenProject) GroupIdMatchesParent() (bool, bool) {
+	if mp.Parent.ArtifactId == "" {
+		return false, false
+	}
+	return mp.Interpolate(mp.EffectiveGroupId()) == mp.Interpolate(mp.Parent.GroupId), true
+}
+
+// EffectiveVersion return the version of the project, inherited from the parent if not declared.
+// The CI friendly placeholders (${revision}, ${sha1} and ${changelist}) are resolved from the
+// properties, undefined ones are left untouched (see Validate).
+func (mp *MavenProject) EffectiveVersion() string {
+	version := mp.Version
+	if version == "" {
+		version = mp.Parent.Version
+	}
+	return interpolateOnce(version, mp.lookupCIFriendlyProperty)
+}
+
+// CIFriendlyProperties list the properties maven allows in the version of a project
+var CIFriendlyProperties = []string{"revision", "sha1", "changelist"}
+
+// lookupCIFriendlyProperty return the value of a CI friendly property
+func (mp *MavenProject) lookupCIFriendlyProperty(key string) (string, bool) {
+	if !contains(CIFriendlyProperties, key) {
+		return "", false
+	}
+	value, exist := mp.Properties[key]
+	return value, exist
+}
+
+// ResolveParent return the parent project using given resolver, or nil if the project has no parent
+func (mp *MavenProject) ResolveParent(resolver ParentResolver) (*MavenProject, error) {
+	if mp.Parent.ArtifactId == "" {
+		return nil, nil
+	}
+
+	parent, err := resolvePOM(resolver, mp.Parent.GroupId, mp.Parent.ArtifactId, mp.Parent.Version)
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve parent %s, %v", mp.Parent.coordinates(), err)
+	}
+	return parent, nil
+}
+
+// resolvePOM return the POM of given coordinates located by resolver, failing when there is no
+// resolver or when it finds no POM without reporting an error
+func resolvePOM(resolver ParentResolver, groupId, artifactId, version string) (*MavenProject, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("no resolver given")
+	}
+	pom, err := resolver.Resolve(groupId, artifactId, version)
+	if err != nil {
+		return nil, err
+	}
+	if pom == nil {
+		return nil, fmt.Errorf("resolver returned no project")
+	}
+	return pom, nil
+}
+
+// EffectiveRelativePath return the location of the parent POM relative to the project directory,
+// defaulting to ../pom.xml when <relativePath> is absent. It returns false when <relativePath/>
+// is explicitly empty, meaning the parent must only be looked up in the repositories.
+func (p Parent) EffectiveRelativePath() (string, bool) {
+	if p.RelativePath == nil {
+		return "../pom.xml", true
+	}
+	if *p.RelativePath == "" {
+		return "", false
+	}
+	return *p.RelativePath, true
+}
+
+// ResolveParent return the parent project, nil if there is none. As in maven, the parent is first
+// looked up on the filesystem at its relativePath from the directory of the file, then using
+// resolver. A POM found on the filesystem is only used if its coordinates match the declared
+// parent. An explicitly empty relativePath skips the filesystem lookup.
+func (pf *ParsedFile) ResolveParent(resolver ParentResolver) (*MavenProject, error) {
+	if pf.Project.Parent.ArtifactId == "" {
+		return nil, nil
+	}
+	parent, err := pf.resolveParentFile(resolver)
+	if err != nil {
+		return nil, err
+	}
+	return parent.Project, nil
+}
+
+// resolveParentFile locate the parent like ResolveParent, the returned file having a path when the
+// parent was found on the filesystem so that its own parent is looked up relatively to it
+func (pf *ParsedFile) resolveParentFile(resolver ParentResolver) (*ParsedFile, error) {
+	parent := pf.Project.Parent
+
+	if relativePath, exist := parent.EffectiveRelativePath(); exist && pf.Path != "" {
+		path := filepath.Join(filepath.Dir(pf.Path), filepath.FromSlash(relativePath))
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, "pom.xml")
+		}
+		if local, err := ParseFile(path); err == nil && local.Project.EffectiveGroupId() == parent.GroupId &&
+			local.Project.ArtifactId == parent.ArtifactId && local.Project.EffectiveVersion() == parent.Version {
+			return local, nil
+		}
+	}
+
+	if resolver == nil && pf.Path != "" {
+		return nil, fmt.Errorf("can't resolve parent %s, not found on the filesystem", parent.coordinates())
+	}
+	project, err := pf.Project.ResolveParent(resolver)
+	if err != nil {
+		return nil, err
+	}
+	return &ParsedFile{Project: project}, nil
+}
+
+// EffectiveParentCoordinates return the actual coordinates of the parent project, the groupId or
+// version it inherits being read from its own <parent> element, or computed through the rest of
+// its parent chain when that element does not declare them either. An error is returned
+// if the coordinates declared in <parent> does not match the actual parent.
+func (mp *MavenProject) EffectiveParentCoordinates(resolver ParentResolver) (Parent, error) {
+	return mp.effectiveParentCoordinates(resolver, map[string]bool{})
+}
+
+func (mp *MavenProject) effectiveParentCoordinates(resolver ParentResolver, seen map[string]bool) (Parent, error) {
+	if mp.Parent.ArtifactId == "" {
+		return Parent{}, fmt.Errorf("project %s has no parent", mp.ArtifactId)
+	}
+	if seen[mp.Parent.coordinates()] {
+		return Parent{}, fmt.Errorf("cycle detected in parent chain at %s", mp.Parent.coordinates())
+	}
+	seen[mp.Parent.coordinates()] = true
+
+	parent, err := mp.ResolveParent(resolver)
+	if err != nil {
+		return Parent{}, err
+	}
+
+	// the inherited groupId and version are declared by the <parent> element of the parent
+	coordinates := Parent{GroupId: parent.EffectiveGroupId(), ArtifactId: parent.ArtifactId, Version: parent.EffectiveVersion()}
+	if coordinates.GroupId == "" || coordinates.Version == "" {
+		grandParent, err := parent.effectiveParentCoordinates(resolver, seen)
+		if err != nil {
+			return Parent{}, err
+		}
+		if coordinates.GroupId == "" {
+			coordinates.GroupId = grandParent.GroupId
+		}
+		if coordinates.Version == "" {
+			coordinates.Version = grandParent.Version
+		}
+	}
+
+	if coordinates.coordinates() != mp.Parent.coordinates() {
+		return Parent{}, fmt.Errorf("parent coordinates mismatch (declared: %s, found: %s)",
+			mp.Parent.coordinates(), coordinates.coordinates())
+	}
+
+	return coordinates, nil
+}
+
+func (p Parent) coordinates() string {
+	return p.GroupId + ":" + p.ArtifactId + ":" + p.Version
+}
@@ -0,0 +1,290 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// mapResolver is a ParentResolver backed by POM contents keyed by groupId:artifactId:version
+type mapResolver map[string]string
+
+func (r mapResolver) Resolve(groupId, artifactId, version string) (*MavenProject, error) {
+	pomStr, ok := r[groupId+":"+artifactId+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("%s:%s:%s not found", groupId, artifactId, version)
+	}
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func TestMavenProject_EffectiveParentCoordinates(t *testing.T) {
+	resolver := mapResolver{
+		"com.example:root:1.0.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0.0</version>
+</project>`,
+		"com.example:parent:1.0.0": `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>parent</artifactId>
+</project>`,
+	}
+
+	project := MavenProject{
+		ArtifactId: "child",
+		Parent:     Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0.0"},
+	}
+
+	parent, err := project.EffectiveParentCoordinates(resolver)
+	if err != nil {
+		t.Fatalf("unable to compute parent coordinates. Reason: %s", err)
+	}
+	if parent.GroupId != "com.example" {
+		t.Errorf("groupId does not match (expected: com.example, found: %s)", parent.GroupId)
+	}
+	if parent.ArtifactId != "parent" {
+		t.Errorf("artifactId does not match (expected: parent, found: %s)", parent.ArtifactId)
+	}
+	if parent.Version != "1.0.0" {
+		t.Errorf("version does not match (expected: 1.0.0, found: %s)", parent.Version)
+	}
+
+	if project.EffectiveGroupId() != "com.example" {
+		t.Errorf("effective groupId does not match (expected: com.example, found: %s)", project.EffectiveGroupId())
+	}
+
+	// the <parent> element of the parent is enough, the root doesn't need to be resolved
+	delete(resolver, "com.example:root:1.0.0")
+	if parent, err := project.EffectiveParentCoordinates(resolver); err != nil || parent.coordinates() != "com.example:parent:1.0.0" {
+		t.Errorf("parent coordinates does not match (expected: com.example:parent:1.0.0, found: %s, %v)", parent.coordinates(), err)
+	}
+}
+
+func TestMavenProject_EffectiveParentCoordinates_Mismatch(t *testing.T) {
+	resolver := mapResolver{
+		"com.example:parent:1.0.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.1.0</version>
+</project>`,
+	}
+
+	project := MavenProject{
+		ArtifactId: "child",
+		Parent:     Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0.0"},
+	}
+
+	if _, err := project.EffectiveParentCoordinates(resolver); err == nil {
+		t.Error("expecting an error for mismatched parent version")
+	}
+}
+
+func TestParsedFile_ResolveParent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "pom.xml", `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <name>from filesystem</name>
+</project>`)
+	resolver := mapResolver{
+		"com.example:parent:1.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <name>from repository</name>
+</project>`,
+	}
+
+	tests := map[string]string{
+		// absent relativePath defaults to ../pom.xml
+		"": "from filesystem",
+		"<relativePath>../pom.xml</relativePath>":       "from filesystem",
+		"<relativePath>..</relativePath>":               "from filesystem",
+		"<relativePath/>":                               "from repository",
+		"<relativePath>../other/pom.xml</relativePath>": "from repository",
+	}
+
+	for relativePath, expected := range tests {
+		path := writeFile(t, dir, "child/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+        `+relativePath+`
+    </parent>
+    <artifactId>child</artifactId>
+</project>`)
+
+		pf, err := ParseFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parent, err := pf.ResolveParent(resolver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parent.Name != expected {
+			t.Errorf("parent with %q does not match (expected: %s, found: %s)", relativePath, expected, parent.Name)
+		}
+	}
+}
+
+func TestMavenProject_EffectiveVersion_CIFriendly(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>${revision}${sha1}${changelist}</version>
+    <properties>
+        <revision>1.2.0</revision>
+        <sha1/>
+        <changelist>-SNAPSHOT</changelist>
+    </properties>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if version := project.EffectiveVersion(); version != "1.2.0-SNAPSHOT" {
+		t.Errorf("version does not match (expected: 1.2.0-SNAPSHOT, found: %s)", version)
+	}
+	if errs := project.Validate(); len(errs) != 0 {
+		t.Errorf("expecting no validation error, found %v", errs)
+	}
+
+	delete(project.Properties, "changelist")
+	if version := project.EffectiveVersion(); version != "1.2.0${changelist}" {
+		t.Errorf("version does not match (expected: 1.2.0${changelist}, found: %s)", version)
+	}
+	errs := project.Validate()
+	if len(errs) != 1 || errs[0].Error() != "version 1.2.0${changelist} uses undefined CI friendly property changelist" {
+		t.Errorf("expecting an undefined changelist error, found %v", errs)
+	}
+}
+
+func TestMavenProject_GroupIdMatchesParent(t *testing.T) {
+	tests := []struct {
+		project   MavenProject
+		matches   bool
+		hasParent bool
+	}{
+		{MavenProject{Parent: Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0"}}, true, true},
+		{MavenProject{GroupId: "${org.groupId}", Properties: Properties{"org.groupId": "com.example"},
+			Parent: Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0"}}, true, true},
+		{MavenProject{GroupId: "com.example.tools", Parent: Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0"}}, false, true},
+		{MavenProject{GroupId: "com.example"}, false, false},
+	}
+	for i, test := range tests {
+		matches, hasParent := test.project.GroupIdMatchesParent()
+		if matches != test.matches || hasParent != test.hasParent {
+			t.Errorf("project[%d] does not match (expected: %v / %v, found: %v / %v)", i, test.matches, test.hasParent, matches, hasParent)
+		}
+	}
+}
+
+// nilResolver find no POM without reporting an error
+type nilResolver struct{}
+
+func (nilResolver) Resolve(groupId, artifactId, version string) (*MavenProject, error) {
+	return nil, nil
+}
+
+func TestMavenProject_ResolveParent_NoPOM(t *testing.T) {
+	project := MavenProject{
+		Parent:     Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0"},
+		ArtifactId: "child",
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "com.example", ArtifactId: "bom", Version: "1.0", Type: "pom", Scope: "import"},
+		}},
+		Dependencies: []Dependency{{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"}},
+	}
+
+	for name, resolver := range map[string]ParentResolver{"nil resolver": nil, "resolver finding nothing": nilResolver{}} {
+		if _, err := project.ResolveParent(resolver); err == nil {
+			t.Errorf("expecting ResolveParent to fail with a %s", name)
+		}
+		if _, err := project.EffectivePOM(resolver); err == nil {
+			t.Errorf("expecting EffectivePOM to fail with a %s", name)
+		}
+		if _, err := project.EffectiveParentCoordinates(resolver); err == nil {
+			t.Errorf("expecting EffectiveParentCoordinates to fail with a %s", name)
+		}
+		if _, err := project.AllPropertyKeys(resolver); err == nil {
+			t.Errorf("expecting AllPropertyKeys to fail with a %s", name)
+		}
+		if _, err := project.DependenciesWithoutLicense(resolver); err == nil {
+			t.Errorf("expecting DependenciesWithoutLicense to fail with a %s", name)
+		}
+		project.PluginsNotManaged(resolver)
+
+		withBOM := project
+		if err := withBOM.ResolveImportedBOMs(resolver); err == nil {
+			t.Errorf("expecting ResolveImportedBOMs to fail with a %s", name)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	childPath := writeFile(t, dir, "child/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+</project>`)
+	if _, err := EffectivePOMBytes(childPath, nil, ActivationContext{}); err == nil {
+		t.Error("expecting EffectivePOMBytes to fail without resolver")
+	}
+}
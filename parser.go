@@ -23,31 +23,96 @@
 package mvnparser
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"os"
+	"reflect"
 	"strings"
 )
 
 // Represent a POM file
 type MavenProject struct {
-	XMLName              xml.Name             `xml:"project"`
-	ModelVersion         string               `xml:"modelVersion"`
-	Parent               Parent               `xml:"parent"`
-	GroupId              string               `xml:"groupId"`
-	ArtifactId           string               `xml:"artifactId"`
-	Version              string               `xml:"version"`
-	Packaging            string               `xml:"packaging"`
-	Name                 string               `xml:"name"`
-	Repositories         []Repository         `xml:"repositories>repository"`
-	Properties           map[string]string    `xml:"properties"`
-	DependencyManagement DependencyManagement `xml:"dependencyManagement"`
-	Dependencies         []Dependency         `xml:"dependencies>dependency"`
-	Profiles             []Profile            `xml:"profiles"`
-	Build                Build                `xml:"build"`
-	PluginRepositories   []PluginRepository   `xml:"pluginRepositories>pluginRepository"`
+	XMLName                xml.Name               `xml:"project"`
+	ModelVersion           string                 `xml:"modelVersion"`
+	Parent                 Parent                 `xml:"parent"`
+	GroupId                string                 `xml:"groupId"`
+	ArtifactId             string                 `xml:"artifactId"`
+	Version                string                 `xml:"version"`
+	Packaging              string                 `xml:"packaging"`
+	Name                   string                 `xml:"name"`
+	Description            *Description           `xml:"description"`
+	Url                    string                 `xml:"url"`
+	Organization           Organization           `xml:"organization"`
+	Licenses               []License              `xml:"licenses>license"`
+	Scm                    Scm                    `xml:"scm"`
+	IssueManagement        IssueManagement        `xml:"issueManagement"`
+	CiManagement           CiManagement           `xml:"ciManagement"`
+	Modules                []string               `xml:"modules>module"`
+	Repositories           []Repository           `xml:"repositories>repository"`
+	Properties             Properties             `xml:"properties"`
+	DependencyManagement   DependencyManagement   `xml:"dependencyManagement"`
+	Dependencies           []Dependency           `xml:"dependencies>dependency"`
+	Profiles               []Profile              `xml:"profiles>profile"`
+	Build                  Build                  `xml:"build"`
+	PluginRepositories     []PluginRepository     `xml:"pluginRepositories>pluginRepository"`
+	DistributionManagement DistributionManagement `xml:"distributionManagement"`
+	// Elements of the project not modelled above (e.g. introduced by a newer modelVersion),
+	// preserved as is and written back after the known ones
+	RawExtensions []Config `xml:",any"`
+
+	// managed dependencies contributed by imported BOMs
+	importedManagement []Dependency
+	// settings of the context the profiles were applied in, resolving the ${settings.*} placeholders
+	settings *Settings
+	// attributes of the parsed project element (e.g. xsi:schemaLocation), written back by Write
+	attrs []xml.Attr
+}
+
+// Represent the properties of the project
+type Properties map[string]string
+
+// Represent a single property element while decoding the properties
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// UnmarshalXML decode each child element of <properties> as a key / value pair
+func (p *Properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = Properties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var prop property
+			if err := d.DecodeElement(&prop, &t); err != nil {
+				return err
+			}
+			(*p)[prop.XMLName.Local] = prop.Value
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Represent a boolean element. Parsing is case insensitive, ignore surrounding whitespace
+// and default to false on empty or unrecognized values.
+type XMLBool bool
+
+// UnmarshalXML decode the text content of the element as a boolean
+func (b *XMLBool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	*b = strings.ToLower(strings.TrimSpace(value)) == "true"
+	return nil
 }
 
 // Represent the parent of the project
@@ -55,6 +120,88 @@ type Parent struct {
 	GroupId    string `xml:"groupId"`
 	ArtifactId string `xml:"artifactId"`
 	Version    string `xml:"version"`
+	// nil when absent, pointing to an empty string when declared as <relativePath/>
+	RelativePath *string `xml:"relativePath"`
+}
+
+// Represent the description of the project. The text is kept verbatim, xml:space="preserve"
+// telling whether its whitespace is significant.
+type Description struct {
+	Space string `xml:"http://www.w3.org/XML/1998/namespace space,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Text return the description text, verbatim if its whitespace is preserved and with the
+// whitespace sequences collapsed into a single space otherwise
+func (d *Description) Text() string {
+	if d == nil {
+		return ""
+	}
+	if d.Space == "preserve" {
+		return d.Value
+	}
+	return strings.Join(strings.Fields(d.Value), " ")
+}
+
+// Represent the organization of the project
+type Organization struct {
+	Name string `xml:"name"`
+	Url  string `xml:"url"`
+}
+
+// Represent a license of the project
+type License struct {
+	Name         string `xml:"name"`
+	Url          string `xml:"url"`
+	Distribution string `xml:"distribution"`
+	Comments     string `xml:"comments"`
+}
+
+// Represent the source control management of the project
+type Scm struct {
+	Connection          string `xml:"connection"`
+	DeveloperConnection string `xml:"developerConnection"`
+	Tag                 string `xml:"tag"`
+	Url                 string `xml:"url"`
+}
+
+// Represent the issue management system of the project
+type IssueManagement struct {
+	System string `xml:"system"`
+	Url    string `xml:"url"`
+}
+
+// Represent the continuous integration system of the project
+type CiManagement struct {
+	System    string     `xml:"system"`
+	Url       string     `xml:"url"`
+	Notifiers []Notifier `xml:"notifiers>notifier"`
+}
+
+// Represent a notifier of the continuous integration system
+type Notifier struct {
+	Type          string     `xml:"type"`
+	Address       string     `xml:"address"`
+	SendOnError   XMLBool    `xml:"sendOnError"`
+	SendOnFailure XMLBool    `xml:"sendOnFailure"`
+	SendOnSuccess XMLBool    `xml:"sendOnSuccess"`
+	SendOnWarning XMLBool    `xml:"sendOnWarning"`
+	Configuration Properties `xml:"configuration"`
+}
+
+// Represent the distribution management of the project
+type DistributionManagement struct {
+	Repository         Repository `xml:"repository"`
+	SnapshotRepository Repository `xml:"snapshotRepository"`
+	Site               Site       `xml:"site"`
+	DownloadUrl        string     `xml:"downloadUrl"`
+}
+
+// Represent the site deployment of the project
+type Site struct {
+	Id   string `xml:"id"`
+	Name string `xml:"name"`
+	Url  string `xml:"url"`
 }
 
 // Represent a dependency of the project
@@ -66,6 +213,7 @@ type Dependency struct {
 	Classifier string      `xml:"classifier"`
 	Type       string      `xml:"type"`
 	Scope      string      `xml:"scope"`
+	Optional   XMLBool     `xml:"optional"`
 	Exclusions []Exclusion `xml:"exclusions>exclusion"`
 }
 
@@ -88,22 +236,78 @@ type Repository struct {
 }
 
 type Profile struct {
-	Id    string `xml:"id"`
-	Build Build  `xml:"build"`
+	Id                   string               `xml:"id"`
+	Activation           Activation           `xml:"activation"`
+	Modules              []string             `xml:"modules>module"`
+	Properties           Properties           `xml:"properties"`
+	DependencyManagement DependencyManagement `xml:"dependencyManagement"`
+	Dependencies         []Dependency         `xml:"dependencies>dependency"`
+	Build                Build                `xml:"build"`
+}
+
+// Represent the conditions activating a profile
+type Activation struct {
+	ActiveByDefault XMLBool            `xml:"activeByDefault"`
+	Jdk             string             `xml:"jdk"`
+	Os              ActivationOS       `xml:"os"`
+	Property        ActivationProperty `xml:"property"`
+}
+
+// Represent an operating system activation condition
+type ActivationOS struct {
+	Name    string `xml:"name"`
+	Family  string `xml:"family"`
+	Arch    string `xml:"arch"`
+	Version string `xml:"version"`
+}
+
+// Represent a property activation condition
+type ActivationProperty struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
 }
 
 type Build struct {
-	// todo: final name ?
+	DefaultGoal      string           `xml:"defaultGoal"`
+	FinalName        string           `xml:"finalName"`
+	SourceDirectory  string           `xml:"sourceDirectory"`
+	Resources        []Resource       `xml:"resources>resource"`
+	TestResources    []Resource       `xml:"testResources>testResource"`
+	Plugins          []Plugin         `xml:"plugins>plugin"`
+	PluginManagement PluginManagement `xml:"pluginManagement"`
+}
+
+type PluginManagement struct {
 	Plugins []Plugin `xml:"plugins>plugin"`
 }
 
+// Represent a resource (or test resource) of the build
+type Resource struct {
+	Directory  string   `xml:"directory"`
+	TargetPath string   `xml:"targetPath"`
+	Filtering  XMLBool  `xml:"filtering"`
+	Includes   []string `xml:"includes>include"`
+	Excludes   []string `xml:"excludes>exclude"`
+}
+
 type Plugin struct {
-	XMLName    xml.Name `xml:"plugin"`
-	GroupId    string   `xml:"groupId"`
-	ArtifactId string   `xml:"artifactId"`
-	Version    string   `xml:"version"`
-	//todo something like: Configuration map[string]string `xml:"configuration"`
-	// todo executions
+	XMLName       xml.Name    `xml:"plugin"`
+	GroupId       string      `xml:"groupId"`
+	ArtifactId    string      `xml:"artifactId"`
+	Version       string      `xml:"version"`
+	Extensions    XMLBool     `xml:"extensions"`
+	Configuration Config      `xml:"configuration"`
+	Executions    []Execution `xml:"executions>execution"`
+	// Goals declared directly under the plugin by older POMs, outside of any execution
+	Goals []string `xml:"goals>goal"`
+}
+
+// Represent a plugin execution binding goals to a lifecycle phase
+type Execution struct {
+	Id            string   `xml:"id"`
+	Phase         string   `xml:"phase"`
+	Goals         []string `xml:"goals>goal"`
+	Configuration Config   `xml:"configuration"`
 }
 
 // Represent a pluginRepository
@@ -113,27 +317,270 @@ type PluginRepository struct {
 	Url  string `xml:"url"`
 }
 
-//Parse a pom.xml file and return the MavenProject representing it.
+// Represent a parsed pom.xml file: the project along with metadata about the document itself
+type ParsedFile struct {
+	Path    string
+	Project *MavenProject
+	// Non fatal issues found while parsing
+	Warnings []string
+	// Comments and processing instructions found before the project element (e.g. a license header),
+	// one per line, excluding the XML declaration
+	Preamble string
+
+	encoding  string
+	namespace string
+}
+
+// XMLEncoding return the encoding declared in the XML prolog, or an empty string if there is none
+func (pf *ParsedFile) XMLEncoding() string {
+	return pf.encoding
+}
+
+// Parse a pom.xml file and return the MavenProject representing it.
 func Parse(pomxmlPath string) (*MavenProject, error) {
+	pf, err := ParseFile(pomxmlPath)
+	if err != nil {
+		return nil, err
+	}
+	return pf.Project, nil
+}
+
+// Parser parse POM files according to its options
+type Parser struct {
+	// Resolver used to locate the parent POMs
+	Resolver ParentResolver
+	// EffectiveOnParse merge the parent chain into the parsed project. ParseFile looks the parents
+	// up at their relativePath first, ParseReader requires Resolver.
+	EffectiveOnParse bool
+	// ValidateNamespace warn when the project declare a namespace other than PomNamespace
+	ValidateNamespace bool
+	// NormalizeCasing lowercase the scope and type of the dependencies (e.g. Compile is compile)
+	NormalizeCasing bool
+}
+
+// PomNamespace is the XML namespace of maven 4.0.0 POM files
+const PomNamespace = "http://maven.apache.org/POM/4.0.0"
+
+// pomNamespacePrefix is shared by the namespaces of every POM model version
+const pomNamespacePrefix = "http://maven.apache.org/POM/"
+
+// ParseFile parse a pom.xml file and return the ParsedFile representing it.
+func ParseFile(pomxmlPath string) (*ParsedFile, error) {
+	return (&Parser{}).ParseFile(pomxmlPath)
+}
+
+// ParseReader parse a POM document and return the ParsedFile representing it.
+func ParseReader(r io.Reader) (*ParsedFile, error) {
+	return (&Parser{}).ParseReader(r)
+}
+
+// ParseFile parse a pom.xml file and return the ParsedFile representing it.
+func (p *Parser) ParseFile(pomxmlPath string) (*ParsedFile, error) {
 	f, err := os.Open(pomxmlPath)
 	if err != nil {
 		return nil, fmt.Errorf("can't open file %s, %v", pomxmlPath, err)
 	}
 	defer f.Close()
 
-	bytes, err := ioutil.ReadAll(f)
+	pf, err := p.parse(f, pomxmlPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse file %s, %v", pomxmlPath, err)
+	}
+	return pf, nil
+}
+
+// ParseReader parse a POM document and return the ParsedFile representing it. Elements are matched
+// by local name, so a namespace prefixed document (<m:project xmlns:m="...">) parse identically to
+// an unprefixed one.
+func (p *Parser) ParseReader(r io.Reader) (*ParsedFile, error) {
+	return p.parse(r, "")
+}
+
+// parse decode the POM document read from path, if any, and apply the options of the parser
+func (p *Parser) parse(r io.Reader, path string) (*ParsedFile, error) {
+	pf, err := decode(r)
 	if err != nil {
-		return nil, fmt.Errorf("can't read file %s, %v", pomxmlPath, err)
+		return nil, err
+	}
+	pf.Path = path
+
+	if p.ValidateNamespace && pf.namespace != "" && pf.namespace != PomNamespace {
+		pf.Warnings = append(pf.Warnings, fmt.Sprintf("unexpected project namespace %s (expected: %s)",
+			pf.namespace, PomNamespace))
+	}
+
+	if p.EffectiveOnParse {
+		if p.Resolver == nil && pf.Path == "" {
+			return nil, fmt.Errorf("EffectiveOnParse requires a Resolver")
+		}
+		if pf.Project, err = pf.effectivePOM(nil, p.Resolver, map[string]bool{}); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.NormalizeCasing {
+		normalizeCasing(pf.Project)
+	}
+
+	return pf, nil
+}
+
+// normalizeCasing lowercase the scope and type of every dependency, including the managed and profile ones
+func normalizeCasing(mp *MavenProject) {
+	lists := [][]Dependency{mp.Dependencies, mp.DependencyManagement.Dependencies}
+	for _, profile := range mp.Profiles {
+		lists = append(lists, profile.Dependencies, profile.DependencyManagement.Dependencies)
+	}
+	for _, deps := range lists {
+		for i := range deps {
+			deps[i].Scope = strings.ToLower(deps[i].Scope)
+			deps[i].Type = strings.ToLower(deps[i].Type)
+		}
 	}
+}
+
+// decode read the POM document, capturing the prolog metadata
+func decode(r io.Reader) (*ParsedFile, error) {
+	var data bytes.Buffer
+	decoder := xml.NewDecoder(io.TeeReader(r, &data))
+	decoder.CharsetReader = charsetReader
+
+	pf := &ParsedFile{}
+	var preamble []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no project element found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal pom file, %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.ProcInst:
+			if t.Target == "xml" {
+				pf.encoding = procInstAttr(string(t.Inst), "encoding")
+			} else {
+				preamble = append(preamble, "<?"+t.Target+" "+string(t.Inst)+"?>")
+			}
+		case xml.Comment:
+			preamble = append(preamble, "<!--"+string(t)+"-->")
+		case xml.StartElement:
+			pf.namespace = t.Name.Space
+			pf.Preamble = strings.Join(preamble, "\n")
+
+			var project MavenProject
+			if err := decoder.DecodeElement(&project, &t); err != nil {
+				return nil, fmt.Errorf("unable to unmarshal pom file, %v", err)
+			}
+			project.attrs = rootAttributes(t)
+
+			// a second pass over the element tree tells the empty lists from the absent ones
+			element, err := decodeElementTree(data.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("unable to unmarshal pom file, %v", err)
+			}
+			markEmptyContainers(reflect.ValueOf(&project).Elem(), element)
+
+			pf.Project = &project
+			return pf, nil
+		}
+	}
+}
+
+// decodeElementTree decode the first element of the document as a generic Config tree
+func decodeElementTree(data []byte) (Config, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return Config{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			var element Config
+			err := decoder.DecodeElement(&element, &start)
+			return element, err
+		}
+	}
+}
+
+// markEmptyContainers set the list fields of the struct v whose container element is declared
+// empty in element (e.g. <modules/>) to an empty non nil slice, which Write keeps
+func markEmptyContainers(v reflect.Value, element Config) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+		name := strings.Split(field.Tag.Get("xml"), ",")[0]
+		if field.PkgPath != "" || name == "" || name == "-" || field.Type == configType {
+			continue
+		}
+
+		path := strings.Split(name, ">")
+		container, exist := element.Child(path[0])
+		if !exist {
+			continue
+		}
+
+		switch {
+		case value.Kind() == reflect.Slice && len(path) == 2:
+			var items []Config
+			for _, child := range container.Children {
+				if child.XMLName.Local == path[1] {
+					items = append(items, child)
+				}
+			}
+			if len(items) == 0 && value.IsNil() {
+				value.Set(reflect.MakeSlice(field.Type, 0, 0))
+			}
+			if field.Type.Elem().Kind() == reflect.Struct {
+				for j := 0; j < len(items) && j < value.Len(); j++ {
+					markEmptyContainers(value.Index(j), items[j])
+				}
+			}
+		case value.Kind() == reflect.Struct:
+			markEmptyContainers(value, container)
+		case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct:
+			markEmptyContainers(value.Elem(), container)
+		}
+	}
+}
+
+// charsetReader convert the single byte encodings commonly declared by POM files to UTF-8
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "iso-8859-1", "iso8859-1", "latin1", "us-ascii", "ascii":
+		bytes, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(bytes))
+		for i, b := range bytes {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %s", label)
+}
 
-	var project MavenProject
-	if err := xml.Unmarshal(bytes, &project); err != nil {
-		log.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+// procInstAttr extract the value of a pseudo attribute (e.g. encoding="UTF-8") of a processing instruction
+func procInstAttr(inst, name string) string {
+	idx := strings.Index(inst, name+"=")
+	if idx == -1 {
+		return ""
+	}
+	value := inst[idx+len(name)+1:]
+	if len(value) == 0 || (value[0] != '"' && value[0] != '\'') {
+		return ""
+	}
+	end := strings.IndexByte(value[1:], value[0])
+	if end == -1 {
+		return ""
 	}
-	return &project, nil
+	return value[1 : end+1]
 }
 
-//GetProperty with a particular key. Case insensitive.
+// GetProperty with a particular key. Case insensitive.
 func (mp *MavenProject) GetProperty(key string) (value string, exist bool) {
 	for k, v := range mp.Properties {
 		if strings.ToLower(k) == strings.ToLower(key) {
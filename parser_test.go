@@ -24,6 +24,10 @@ package mvnparser
 
 import (
 	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -261,3 +265,408 @@ func TestUnmarshal(t *testing.T) {
 		t.Errorf("pluginRepository[0] url does not match (expected: http://localhost:8081/repository/maven-private/, found: %s)", project.PluginRepositories[0].Url)
 	}
 }
+
+func TestXMLBool_UnmarshalXML(t *testing.T) {
+	tests := map[string]XMLBool{
+		"<optional>true</optional>":    true,
+		"<optional>TRUE</optional>":    true,
+		"<optional> false </optional>": false,
+		"<optional></optional>":        false,
+	}
+
+	for xmlStr, expected := range tests {
+		var value XMLBool
+		if err := xml.Unmarshal([]byte(xmlStr), &value); err != nil {
+			t.Errorf("unable to unmarshal %s. Reason: %s", xmlStr, err)
+		}
+		if value != expected {
+			t.Errorf("value does not match for %s (expected: %t, found: %t)", xmlStr, expected, value)
+		}
+	}
+}
+
+func TestProperties_UnmarshalXML_CDATA(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <build.number>42</build.number>
+        <script><![CDATA[if (a < b && c > d) { echo "<done/>"; }]]></script>
+    </properties>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if project.Properties["build.number"] != "42" {
+		t.Errorf("build.number does not match (expected: 42, found: %s)", project.Properties["build.number"])
+	}
+	expected := `if (a < b && c > d) { echo "<done/>"; }`
+	if project.Properties["script"] != expected {
+		t.Errorf("script does not match (expected: %s, found: %s)", expected, project.Properties["script"])
+	}
+}
+
+func TestParseReader_XMLEncoding(t *testing.T) {
+	pomStr := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+		"<project><artifactId>my-app</artifactId><name>Alo\xefs</name></project>"
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+
+	if pf.XMLEncoding() != "ISO-8859-1" {
+		t.Errorf("encoding does not match (expected: ISO-8859-1, found: %s)", pf.XMLEncoding())
+	}
+	if pf.Project.ArtifactId != "my-app" {
+		t.Errorf("artifactId does not match (expected: my-app, found: %s)", pf.Project.ArtifactId)
+	}
+	if pf.Project.Name != "Aloïs" {
+		t.Errorf("name does not match (expected: Aloïs, found: %s)", pf.Project.Name)
+	}
+
+	pf, err = ParseReader(strings.NewReader("<project><artifactId>my-app</artifactId></project>"))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	if pf.XMLEncoding() != "" {
+		t.Errorf("expecting no encoding, found %s", pf.XMLEncoding())
+	}
+}
+
+func TestParser_EffectiveOnParse(t *testing.T) {
+	parser := Parser{
+		Resolver: mapResolver{
+			"com.example:parent:1.0.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <properties>
+        <junit.version>4.12</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.version}</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+</project>`,
+		},
+		EffectiveOnParse: true,
+	}
+
+	pf, err := parser.ParseReader(strings.NewReader(`
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>my-app</artifactId>
+</project>`))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+
+	project := pf.Project
+	if project.GroupId != "com.example" || project.Version != "1.0.0" {
+		t.Errorf("coordinates does not match (expected: com.example:my-app:1.0.0, found: %s:%s:%s)",
+			project.GroupId, project.ArtifactId, project.Version)
+	}
+	deps := project.ResolvedDependencies()
+	if len(deps) != 1 || deps[0].Version != "4.12" {
+		t.Errorf("expecting inherited junit 4.12 dependency, found %v", deps)
+	}
+
+	if _, err := (&Parser{EffectiveOnParse: true}).ParseReader(strings.NewReader("<project/>")); err == nil {
+		t.Error("expecting an error when EffectiveOnParse is set without Resolver")
+	}
+}
+
+func TestParser_EffectiveOnParse_RelativePath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "pom.xml", `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <junit.version>4.12</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.version}</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+</project>`)
+	childPath := writeFile(t, dir, "app/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>my-app</artifactId>
+</project>`)
+
+	// no resolver, the parent is found at the default relativePath
+	pf, err := (&Parser{EffectiveOnParse: true}).ParseFile(childPath)
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	if pf.Path != childPath {
+		t.Errorf("path does not match (expected: %s, found: %s)", childPath, pf.Path)
+	}
+	deps := pf.Project.ResolvedDependencies()
+	if len(deps) != 1 || deps[0].Version != "4.12" {
+		t.Errorf("expecting inherited junit 4.12 dependency, found %v", deps)
+	}
+}
+
+func TestCiManagement_Notifiers(t *testing.T) {
+	pomStr := `
+<project>
+    <ciManagement>
+        <system>continuum</system>
+        <url>http://127.0.0.1:8080/continuum</url>
+        <notifiers>
+            <notifier>
+                <type>mail</type>
+                <sendOnError>true</sendOnError>
+                <sendOnFailure>true</sendOnFailure>
+                <sendOnSuccess>false</sendOnSuccess>
+                <sendOnWarning>false</sendOnWarning>
+                <configuration>
+                    <address>continuum@127.0.0.1</address>
+                </configuration>
+            </notifier>
+        </notifiers>
+    </ciManagement>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if len(project.CiManagement.Notifiers) != 1 {
+		t.Fatalf("expecting 1 notifier found %d", len(project.CiManagement.Notifiers))
+	}
+	notifier := project.CiManagement.Notifiers[0]
+	if notifier.Type != "mail" {
+		t.Errorf("type does not match (expected: mail, found: %s)", notifier.Type)
+	}
+	if !notifier.SendOnError || !notifier.SendOnFailure || notifier.SendOnSuccess || notifier.SendOnWarning {
+		t.Errorf("send flags does not match (expected: true/true/false/false, found: %+v)", notifier)
+	}
+	if notifier.Configuration["address"] != "continuum@127.0.0.1" {
+		t.Errorf("address does not match (expected: continuum@127.0.0.1, found: %s)", notifier.Configuration["address"])
+	}
+}
+
+func TestParser_ValidateNamespace(t *testing.T) {
+	parser := Parser{ValidateNamespace: true}
+
+	pf, err := parser.ParseReader(strings.NewReader(`
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+</project>`))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	if pf.Project.ArtifactId != "my-app" {
+		t.Errorf("artifactId does not match (expected: my-app, found: %s)", pf.Project.ArtifactId)
+	}
+	if len(pf.Warnings) != 0 {
+		t.Errorf("expecting no warning found %v", pf.Warnings)
+	}
+
+	pf, err = parser.ParseReader(strings.NewReader(`
+<project xmlns="http://maven.apache.org/POM/3.0.0">
+    <artifactId>my-app</artifactId>
+</project>`))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	expected := "unexpected project namespace http://maven.apache.org/POM/3.0.0 (expected: http://maven.apache.org/POM/4.0.0)"
+	if len(pf.Warnings) != 1 || pf.Warnings[0] != expected {
+		t.Errorf("warnings does not match (expected: [%s], found: %v)", expected, pf.Warnings)
+	}
+}
+
+func TestParser_NormalizeCasing(t *testing.T) {
+	pomStr := `
+<project>
+    <artifactId>my-app</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <scope>Compile</scope>
+            <type>JAR</type>
+        </dependency>
+    </dependencies>
+    <profiles>
+        <profile>
+            <id>it</id>
+            <dependencies>
+                <dependency>
+                    <groupId>org.mockito</groupId>
+                    <artifactId>mockito-core</artifactId>
+                    <scope>TEST</scope>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`
+
+	pf, err := (&Parser{NormalizeCasing: true}).ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	dep := pf.Project.Dependencies[0]
+	if dep.Scope != "compile" || dep.Type != "jar" {
+		t.Errorf("scope / type does not match (expected: compile / jar, found: %s / %s)", dep.Scope, dep.Type)
+	}
+	if scope := pf.Project.Profiles[0].Dependencies[0].Scope; scope != "test" {
+		t.Errorf("profile dependency scope does not match (expected: test, found: %s)", scope)
+	}
+
+	pf, err = ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	dep = pf.Project.Dependencies[0]
+	if dep.Scope != "Compile" || dep.Type != "JAR" {
+		t.Errorf("scope / type does not match (expected: Compile / JAR, found: %s / %s)", dep.Scope, dep.Type)
+	}
+}
+
+func TestPlugin_Goals(t *testing.T) {
+	pomStr := `
+<project>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>build-helper-maven-plugin</artifactId>
+                <goals>
+                    <goal>add-source</goal>
+                </goals>
+                <executions>
+                    <execution>
+                        <id>timestamp</id>
+                        <phase>validate</phase>
+                        <goals>
+                            <goal>timestamp-property</goal>
+                        </goals>
+                        <configuration>
+                            <name>build.time</name>
+                        </configuration>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	plugin := project.Build.Plugins[0]
+	if len(plugin.Goals) != 1 || plugin.Goals[0] != "add-source" {
+		t.Errorf("plugin goals do not match (expected: [add-source], found: %v)", plugin.Goals)
+	}
+	if len(plugin.Executions) != 1 {
+		t.Fatalf("expecting 1 execution found %d", len(plugin.Executions))
+	}
+	execution := plugin.Executions[0]
+	if execution.Id != "timestamp" || execution.Phase != "validate" {
+		t.Errorf("execution does not match (expected: timestamp@validate, found: %s@%s)", execution.Id, execution.Phase)
+	}
+	if len(execution.Goals) != 1 || execution.Goals[0] != "timestamp-property" {
+		t.Errorf("execution goals do not match (expected: [timestamp-property], found: %v)", execution.Goals)
+	}
+	if name, _ := execution.Configuration.Lookup("name"); name != "build.time" {
+		t.Errorf("execution configuration does not match (expected: build.time, found: %s)", name)
+	}
+}
+
+func TestParseReader_PrefixedNamespace(t *testing.T) {
+	prefixed := `<?xml version="1.0" encoding="UTF-8"?>
+<m:project xmlns:m="http://maven.apache.org/POM/4.0.0">
+    <m:modelVersion>4.0.0</m:modelVersion>
+    <m:groupId>com.example</m:groupId>
+    <m:artifactId>my-app</m:artifactId>
+    <m:version>1.0.0</m:version>
+    <m:properties>
+        <m:slf4j.version>1.7.30</m:slf4j.version>
+    </m:properties>
+    <m:dependencies>
+        <m:dependency>
+            <m:groupId>org.slf4j</m:groupId>
+            <m:artifactId>slf4j-api</m:artifactId>
+            <m:version>${slf4j.version}</m:version>
+        </m:dependency>
+    </m:dependencies>
+</m:project>`
+	unprefixed := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.0.0</version>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	parser := Parser{ValidateNamespace: true}
+	pf, err := parser.ParseReader(strings.NewReader(prefixed))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	project := pf.Project
+	if project.GroupId != "com.example" || project.ArtifactId != "my-app" || project.Version != "1.0.0" {
+		t.Errorf("coordinates does not match (expected: com.example:my-app:1.0.0, found: %s:%s:%s)",
+			project.GroupId, project.ArtifactId, project.Version)
+	}
+	if len(pf.Warnings) != 0 {
+		t.Errorf("expecting no warning found %v", pf.Warnings)
+	}
+	if deps := project.ResolvedDependencies(); len(deps) != 1 || deps[0].Version != "1.7.30" {
+		t.Errorf("expecting slf4j-api 1.7.30, found %v", deps)
+	}
+
+	expected, err := ParseReader(strings.NewReader(unprefixed))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	if !reflect.DeepEqual(project, expected.Project) {
+		t.Errorf("prefixed project does not match the unprefixed one (expected: %+v, found: %+v)", expected.Project, project)
+	}
+}
@@ -0,0 +1,159 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rules a project is checked against by CheckPolicy
+const (
+	RuleBannedArtifact = "banned-artifact"
+	RuleRequiredPlugin = "required-plugin"
+	RuleMinimumVersion = "minimum-version"
+	RuleForbiddenScope = "forbidden-scope"
+	RuleNoSnapshots    = "no-snapshots"
+)
+
+// Represent the governance rules a project must comply with
+type Policy struct {
+	// Dependencies and build plugins that must not be used. The version is optional and may be a
+	// range, as for BannedPlugins (the groupId of a plugin defaults to org.apache.maven.plugins).
+	BannedArtifacts []Artifact
+	// Build plugins that must be declared, as groupId:artifactId or artifactId for the
+	// org.apache.maven.plugins ones
+	RequiredPlugins []string
+	// Minimum version of the dependencies and build plugins, keyed by groupId:artifactId
+	MinimumVersions map[string]string
+	// Scopes the dependencies must not use (e.g. system)
+	ForbiddenScopes []string
+	// NoSnapshots forbid SNAPSHOT versions of the dependencies and build plugins
+	NoSnapshots bool
+}
+
+// Represent a policy rule the project does not comply with
+type Violation struct {
+	// The rule violated, one of the Rule* constants
+	Rule string
+	// The groupId:artifactId[:version] of the offending dependency or plugin
+	Artifact string
+	Message  string
+}
+
+// String return the rule followed by the message of the violation
+func (v Violation) String() string {
+	return v.Rule + ": " + v.Message
+}
+
+// CheckPolicy return the violations of the policy by the resolved dependencies and build plugins of
+// the project (managed versions applied), grouped by rule in the order of the Rule* constants
+func (mp *MavenProject) CheckPolicy(policy Policy) []Violation {
+	deps := mp.ResolvedDependencies()
+	plugins := mp.ResolvedPlugins()
+
+	var violations []Violation
+	add := func(rule string, artifact Artifact, format string, args ...interface{}) {
+		violations = append(violations, Violation{Rule: rule, Artifact: artifact.String(), Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, dep := range deps {
+		for _, ban := range policy.BannedArtifacts {
+			if ban.GroupId == dep.GroupId && ban.ArtifactId == dep.ArtifactId && ban.matchesVersion(dep.Version) {
+				add(RuleBannedArtifact, dep.artifact(), "dependency %s is banned (%s)", dep.artifact(), ban)
+				break
+			}
+		}
+	}
+	for _, plugin := range mp.BannedPlugins(policy.BannedArtifacts) {
+		add(RuleBannedArtifact, plugin.artifact(), "plugin %s is banned", plugin.artifact())
+	}
+
+	for _, required := range policy.RequiredPlugins {
+		if !strings.Contains(required, ":") {
+			required = "org.apache.maven.plugins:" + required
+		}
+		if _, exist := mp.plugin(required); !exist {
+			groupId, artifactId := splitKey(required)
+			add(RuleRequiredPlugin, Artifact{GroupId: groupId, ArtifactId: artifactId}, "plugin %s is required", required)
+		}
+	}
+
+	// the minimum versions and snapshots rules apply to both dependencies and plugins
+	type declared struct {
+		kind     string
+		artifact Artifact
+	}
+	var artifacts []declared
+	for _, dep := range deps {
+		artifacts = append(artifacts, declared{"dependency", dep.artifact()})
+	}
+	for _, plugin := range plugins {
+		artifacts = append(artifacts, declared{"plugin", plugin.artifact()})
+	}
+
+	for _, d := range artifacts {
+		minimum, exist := policy.MinimumVersions[d.artifact.GroupId+":"+d.artifact.ArtifactId]
+		if exist && d.artifact.Version != "" && CompareVersions(d.artifact.Version, minimum) < 0 {
+			add(RuleMinimumVersion, d.artifact, "%s %s is older than %s", d.kind, d.artifact, minimum)
+		}
+	}
+
+	for _, dep := range deps {
+		for _, scope := range policy.ForbiddenScopes {
+			if dep.EffectiveScope() == scope {
+				add(RuleForbiddenScope, dep.artifact(), "dependency %s use the forbidden scope %s", dep.artifact(), scope)
+				break
+			}
+		}
+	}
+
+	if policy.NoSnapshots {
+		for _, d := range artifacts {
+			if IsSnapshot(d.artifact.Version) {
+				add(RuleNoSnapshots, d.artifact, "%s %s is a snapshot", d.kind, d.artifact)
+			}
+		}
+	}
+
+	return violations
+}
+
+// artifact return the coordinates of the dependency
+func (d Dependency) artifact() Artifact {
+	return Artifact{GroupId: d.GroupId, ArtifactId: d.ArtifactId, Version: d.Version}
+}
+
+// artifact return the coordinates of the plugin, its groupId defaulted
+func (p Plugin) artifact() Artifact {
+	return Artifact{GroupId: p.EffectiveGroupId(), ArtifactId: p.ArtifactId, Version: p.Version}
+}
+
+// splitKey split a groupId:artifactId key
+func splitKey(key string) (groupId, artifactId string) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMavenProject_CheckPolicy(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <log4j.version>1.2.17</log4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.fasterxml.jackson.core</groupId>
+                <artifactId>jackson-databind</artifactId>
+                <version>2.9.8</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>log4j</groupId>
+            <artifactId>log4j</artifactId>
+            <version>${log4j.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-databind</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>com.sun</groupId>
+            <artifactId>tools</artifactId>
+            <version>1.8</version>
+            <scope>system</scope>
+        </dependency>
+        <dependency>
+            <groupId>org.example</groupId>
+            <artifactId>common</artifactId>
+            <version>1.0-SNAPSHOT</version>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.8.1</version>
+            </plugin>
+            <plugin>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <version>2.12.4</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		expected []Violation
+	}{
+		{"banned artifacts", Policy{BannedArtifacts: []Artifact{
+			{GroupId: "log4j", ArtifactId: "log4j"},
+			{ArtifactId: "maven-surefire-plugin", Version: "(,2.22.0)"},
+			{GroupId: "com.sun", ArtifactId: "tools", Version: "[9,)"},
+		}}, []Violation{
+			{RuleBannedArtifact, "log4j:log4j:1.2.17", "dependency log4j:log4j:1.2.17 is banned (log4j:log4j)"},
+			{RuleBannedArtifact, "org.apache.maven.plugins:maven-surefire-plugin:2.12.4",
+				"plugin org.apache.maven.plugins:maven-surefire-plugin:2.12.4 is banned"},
+		}},
+		{"required plugins", Policy{RequiredPlugins: []string{"maven-compiler-plugin", "org.apache.maven.plugins:maven-enforcer-plugin"}}, []Violation{
+			{RuleRequiredPlugin, "org.apache.maven.plugins:maven-enforcer-plugin",
+				"plugin org.apache.maven.plugins:maven-enforcer-plugin is required"},
+		}},
+		{"minimum versions", Policy{MinimumVersions: map[string]string{
+			"com.fasterxml.jackson.core:jackson-databind":    "2.12.0",
+			"org.apache.maven.plugins:maven-compiler-plugin": "3.8.0",
+			"org.apache.maven.plugins:maven-surefire-plugin": "2.22.0",
+		}}, []Violation{
+			{RuleMinimumVersion, "com.fasterxml.jackson.core:jackson-databind:2.9.8",
+				"dependency com.fasterxml.jackson.core:jackson-databind:2.9.8 is older than 2.12.0"},
+			{RuleMinimumVersion, "org.apache.maven.plugins:maven-surefire-plugin:2.12.4",
+				"plugin org.apache.maven.plugins:maven-surefire-plugin:2.12.4 is older than 2.22.0"},
+		}},
+		{"forbidden scopes", Policy{ForbiddenScopes: []string{"system"}}, []Violation{
+			{RuleForbiddenScope, "com.sun:tools:1.8", "dependency com.sun:tools:1.8 use the forbidden scope system"},
+		}},
+		{"no snapshots", Policy{NoSnapshots: true}, []Violation{
+			{RuleNoSnapshots, "org.example:common:1.0-SNAPSHOT", "dependency org.example:common:1.0-SNAPSHOT is a snapshot"},
+		}},
+	}
+	for _, test := range tests {
+		violations := project.CheckPolicy(test.policy)
+		if !reflect.DeepEqual(violations, test.expected) {
+			t.Errorf("%s violations does not match (expected: %v, found: %v)", test.name, test.expected, violations)
+		}
+	}
+
+	clean := Policy{
+		BannedArtifacts: []Artifact{{GroupId: "commons-logging", ArtifactId: "commons-logging"}},
+		RequiredPlugins: []string{"maven-compiler-plugin"},
+		MinimumVersions: map[string]string{"log4j:log4j": "1.2.17"},
+		ForbiddenScopes: []string{"import"},
+	}
+	if violations := project.CheckPolicy(clean); len(violations) != 0 {
+		t.Errorf("expecting no violation found %v", violations)
+	}
+}
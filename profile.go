@@ -0,0 +1,290 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Represent the environment used to decide which profiles are active
+type ActivationContext struct {
+	// Profiles explicitly activated (-P id)
+	ActiveProfiles []string
+	// Profiles explicitly deactivated (-P !id)
+	InactiveProfiles []string
+	// System and user properties (-D key=value)
+	Properties map[string]string
+	JDK        string
+	OSName     string
+	OSFamily   string
+	OSArch     string
+	OSVersion  string
+	// Settings resolving the ${settings.*} placeholders, if any
+	Settings *Settings
+}
+
+// ActiveProfiles return the profiles of the project active in given context. Profiles
+// marked activeByDefault are only active when no other profile of the project is.
+func (mp *MavenProject) ActiveProfiles(ctx ActivationContext) []Profile {
+	var active, byDefault []Profile
+	for _, profile := range mp.Profiles {
+		if contains(ctx.InactiveProfiles, profile.Id) {
+			continue
+		}
+		if contains(ctx.ActiveProfiles, profile.Id) || profile.matches(ctx) {
+			active = append(active, profile)
+		} else if profile.Activation.ActiveByDefault {
+			byDefault = append(byDefault, profile)
+		}
+	}
+
+	if len(active) == 0 {
+		return byDefault
+	}
+	return active
+}
+
+// EffectiveModules return the modules of the project combined with the ones of the active profiles
+func (mp *MavenProject) EffectiveModules(ctx ActivationContext) []string {
+	var modules []string
+	seen := map[string]bool{}
+	add := func(values []string) {
+		for _, module := range values {
+			if !seen[module] {
+				seen[module] = true
+				modules = append(modules, module)
+			}
+		}
+	}
+
+	add(mp.Modules)
+	for _, profile := range mp.ActiveProfiles(ctx) {
+		add(profile.Modules)
+	}
+	return modules
+}
+
+// ApplyProfiles return a copy of the project with the profiles active in given context merged
+// into it. Profile properties, dependencies, plugins and source directory take precedence over the
+// project ones. The settings of the context, if any, are kept to resolve the ${settings.*}
+// placeholders of the returned project.
+func (mp *MavenProject) ApplyProfiles(ctx ActivationContext) *MavenProject {
+	applied := *mp
+	if ctx.Settings != nil {
+		applied.settings = ctx.Settings
+	}
+	applied.Modules = mp.EffectiveModules(ctx)
+	applied.Properties = Properties{}
+	for k, v := range mp.Properties {
+		applied.Properties[k] = v
+	}
+
+	for _, profile := range mp.ActiveProfiles(ctx) {
+		for k, v := range profile.Properties {
+			applied.Properties[k] = v
+		}
+		applied.DependencyManagement.Dependencies = mergeDependencies(profile.DependencyManagement.Dependencies,
+			applied.DependencyManagement.Dependencies)
+		applied.Dependencies = mergeDependencies(profile.Dependencies, applied.Dependencies)
+		applied.Build.Plugins = mergePlugins(profile.Build.Plugins, applied.Build.Plugins)
+		applied.Build.PluginManagement.Plugins = mergePlugins(profile.Build.PluginManagement.Plugins,
+			applied.Build.PluginManagement.Plugins)
+		if profile.Build.SourceDirectory != "" {
+			applied.Build.SourceDirectory = profile.Build.SourceDirectory
+		}
+	}
+	return &applied
+}
+
+// IsActive evaluate the activation of the profile alone in given context and explain the decision
+// (e.g. "activated by property env=prod" or "skipped: jdk 8 not in [11,)"). Unlike ActiveProfiles,
+// a profile marked activeByDefault is reported active regardless of the other profiles.
+func (p Profile) IsActive(ctx ActivationContext) (bool, string) {
+	if contains(ctx.InactiveProfiles, p.Id) {
+		return false, "skipped: deactivated explicitly"
+	}
+	if contains(ctx.ActiveProfiles, p.Id) {
+		return true, "activated explicitly"
+	}
+	if active, reason := p.evaluate(ctx); active || reason != "" {
+		return active, reason
+	}
+	if p.Activation.ActiveByDefault {
+		return true, "activated by default"
+	}
+	return false, "skipped: no activation condition"
+}
+
+// matches return true if the profile declare activation conditions and they are all met
+func (p Profile) matches(ctx ActivationContext) bool {
+	active, _ := p.evaluate(ctx)
+	return active
+}
+
+// evaluate check the activation conditions of the profile, returning whether they are all met
+// along with the reason. The reason is empty when the profile declare no condition.
+func (p Profile) evaluate(ctx ActivationContext) (bool, string) {
+	activation := p.Activation
+	var met []string
+
+	if activation.Jdk != "" {
+		if !matchJDK(activation.Jdk, ctx.JDK) {
+			if strings.ContainsAny(activation.Jdk, "[(") {
+				return false, fmt.Sprintf("skipped: jdk %s not in %s", ctx.JDK, activation.Jdk)
+			}
+			return false, fmt.Sprintf("skipped: jdk %s does not match %s", ctx.JDK, activation.Jdk)
+		}
+		met = append(met, fmt.Sprintf("jdk %s", ctx.JDK))
+	}
+
+	os := activation.Os
+	for _, condition := range [][3]string{
+		{"name", os.Name, ctx.OSName},
+		{"family", os.Family, ctx.OSFamily},
+		{"arch", os.Arch, ctx.OSArch},
+		{"version", os.Version, ctx.OSVersion},
+	} {
+		field, expected, actual := condition[0], condition[1], condition[2]
+		if expected == "" {
+			continue
+		}
+		if !matchNegatable(expected, func(value string) bool {
+			return strings.EqualFold(value, actual)
+		}) {
+			return false, fmt.Sprintf("skipped: os %s %s does not match %s", field, actual, expected)
+		}
+		met = append(met, fmt.Sprintf("os %s %s", field, actual))
+	}
+
+	if activation.Property.Name != "" {
+		name := activation.Property.Name
+		if strings.HasPrefix(name, "!") {
+			if _, exist := ctx.Properties[name[1:]]; exist {
+				return false, fmt.Sprintf("skipped: property %s is set", name[1:])
+			}
+			met = append(met, fmt.Sprintf("property %s", name))
+		} else {
+			value, exist := ctx.Properties[name]
+			if !exist {
+				return false, fmt.Sprintf("skipped: property %s is not set", name)
+			}
+			if activation.Property.Value != "" && !matchNegatable(activation.Property.Value, func(expected string) bool {
+				return value == expected
+			}) {
+				return false, fmt.Sprintf("skipped: property %s=%s does not match %s", name, value, activation.Property.Value)
+			}
+			met = append(met, fmt.Sprintf("property %s=%s", name, value))
+		}
+	}
+
+	if len(met) == 0 {
+		return false, ""
+	}
+	return true, "activated by " + strings.Join(met, " and ")
+}
+
+// matchJDK return true if the jdk version satisfies the condition: a version range such
+// as [11,) or a version prefix such as 1.8, optionally negated with '!'
+func matchJDK(condition, jdk string) bool {
+	if strings.ContainsAny(condition, "[(") {
+		vr, err := ParseVersionRange(condition)
+		return err == nil && jdk != "" && vr.Contains(jdk)
+	}
+	return matchNegatable(condition, func(prefix string) bool {
+		return strings.HasPrefix(jdk, prefix)
+	})
+}
+
+// matchNegatable apply match to the condition, inverting the result when prefixed by '!'
+func matchNegatable(condition string, match func(string) bool) bool {
+	if strings.HasPrefix(condition, "!") {
+		return !match(condition[1:])
+	}
+	return match(condition)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CommonProfileDependencies return the dependencies declared by every profile of the project,
+// as declared by the first profile. Such dependencies are candidates to move to the base dependencies.
+func (mp *MavenProject) CommonProfileDependencies() []Dependency {
+	if len(mp.Profiles) == 0 {
+		return nil
+	}
+
+	var common []Dependency
+	for _, dep := range mp.Profiles[0].Dependencies {
+		shared := true
+		for _, profile := range mp.Profiles[1:] {
+			declared := false
+			for _, other := range profile.Dependencies {
+				if other.SameArtifact(dep) {
+					declared = true
+					break
+				}
+			}
+			if !declared {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			common = append(common, dep)
+		}
+	}
+	return common
+}
+
+// ProfilesAffectingDependencies return the ids of the profiles whose activation adds, removes or
+// re-versions a resolved dependency of the project, including through a version property
+func (mp *MavenProject) ProfilesAffectingDependencies() []string {
+	versions := func(deps []Dependency) map[string]string {
+		m := map[string]string{}
+		for _, dep := range deps {
+			m[dep.key()] = dep.Version
+		}
+		return m
+	}
+	base := versions(mp.ResolvedDependencies())
+
+	var ids []string
+	for _, profile := range mp.Profiles {
+		// apply the profile alone, the other ones could be activated by a negated condition
+		alone := *mp
+		alone.Profiles = []Profile{profile}
+		applied := versions(alone.ApplyProfiles(ActivationContext{ActiveProfiles: []string{profile.Id}}).ResolvedDependencies())
+		if !reflect.DeepEqual(base, applied) {
+			ids = append(ids, profile.Id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,260 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMavenProject_EffectiveModules(t *testing.T) {
+	pomStr := `
+<project>
+    <modules>
+        <module>core</module>
+        <module>web</module>
+    </modules>
+    <profiles>
+        <profile>
+            <id>integration</id>
+            <activation>
+                <property>
+                    <name>env</name>
+                    <value>ci</value>
+                </property>
+            </activation>
+            <modules>
+                <module>core</module>
+                <module>integration-tests</module>
+            </modules>
+        </profile>
+        <profile>
+            <id>docs</id>
+            <modules>
+                <module>docs</module>
+            </modules>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if len(project.Profiles) != 2 {
+		t.Fatalf("expecting 2 profiles found %d", len(project.Profiles))
+	}
+
+	modules := project.EffectiveModules(ActivationContext{Properties: map[string]string{"env": "ci"}})
+	expected := []string{"core", "web", "integration-tests"}
+	if len(modules) != len(expected) {
+		t.Fatalf("expecting %d modules found %d (%v)", len(expected), len(modules), modules)
+	}
+	for i, module := range expected {
+		if modules[i] != module {
+			t.Errorf("module[%d] does not match (expected: %s, found: %s)", i, module, modules[i])
+		}
+	}
+
+	modules = project.EffectiveModules(ActivationContext{})
+	if len(modules) != 2 {
+		t.Errorf("expecting 2 modules found %d (%v)", len(modules), modules)
+	}
+}
+
+func TestMavenProject_ActiveProfiles(t *testing.T) {
+	project := MavenProject{
+		Profiles: []Profile{
+			{Id: "default", Activation: Activation{ActiveByDefault: true}},
+			{Id: "java8", Activation: Activation{Jdk: "1.8"}},
+			{Id: "not-windows", Activation: Activation{Os: ActivationOS{Family: "!windows"}}},
+		},
+	}
+
+	profiles := project.ActiveProfiles(ActivationContext{JDK: "11.0.2", OSFamily: "windows"})
+	if len(profiles) != 1 || profiles[0].Id != "default" {
+		t.Errorf("expecting default profile to be active, found %v", profiles)
+	}
+
+	profiles = project.ActiveProfiles(ActivationContext{JDK: "1.8.0_202", OSFamily: "windows"})
+	if len(profiles) != 1 || profiles[0].Id != "java8" {
+		t.Errorf("expecting java8 profile to be active, found %v", profiles)
+	}
+
+	profiles = project.ActiveProfiles(ActivationContext{ActiveProfiles: []string{"default"}, OSFamily: "unix"})
+	if len(profiles) != 2 {
+		t.Errorf("expecting 2 active profiles, found %v", profiles)
+	}
+}
+
+func TestProfile_IsActive(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		ctx     ActivationContext
+		active  bool
+		reason  string
+	}{
+		{
+			profile: Profile{Id: "prod", Activation: Activation{Property: ActivationProperty{Name: "env", Value: "prod"}}},
+			ctx:     ActivationContext{Properties: map[string]string{"env": "prod"}},
+			active:  true,
+			reason:  "activated by property env=prod",
+		},
+		{
+			profile: Profile{Id: "prod", Activation: Activation{Property: ActivationProperty{Name: "env", Value: "prod"}}},
+			ctx:     ActivationContext{Properties: map[string]string{"env": "dev"}},
+			active:  false,
+			reason:  "skipped: property env=dev does not match prod",
+		},
+		{
+			profile: Profile{Id: "java11", Activation: Activation{Jdk: "[11,)"}},
+			ctx:     ActivationContext{JDK: "8"},
+			active:  false,
+			reason:  "skipped: jdk 8 not in [11,)",
+		},
+		{
+			profile: Profile{Id: "java11", Activation: Activation{Jdk: "[11,)", Os: ActivationOS{Family: "unix"}}},
+			ctx:     ActivationContext{JDK: "17.0.1", OSFamily: "unix"},
+			active:  true,
+			reason:  "activated by jdk 17.0.1 and os family unix",
+		},
+		{
+			profile: Profile{Id: "default", Activation: Activation{ActiveByDefault: true}},
+			ctx:     ActivationContext{InactiveProfiles: []string{"default"}},
+			active:  false,
+			reason:  "skipped: deactivated explicitly",
+		},
+		{
+			profile: Profile{Id: "release"},
+			ctx:     ActivationContext{},
+			active:  false,
+			reason:  "skipped: no activation condition",
+		},
+	}
+
+	for _, test := range tests {
+		active, reason := test.profile.IsActive(test.ctx)
+		if active != test.active {
+			t.Errorf("activation of %s does not match (expected: %v, found: %v)", test.profile.Id, test.active, active)
+		}
+		if reason != test.reason {
+			t.Errorf("reason does not match (expected: %s, found: %s)", test.reason, reason)
+		}
+	}
+}
+
+func TestMavenProject_CommonProfileDependencies(t *testing.T) {
+	slf4j := Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"}
+	project := MavenProject{
+		Profiles: []Profile{
+			{Id: "dev", Dependencies: []Dependency{
+				slf4j,
+				{GroupId: "com.h2database", ArtifactId: "h2"},
+			}},
+			{Id: "test", Dependencies: []Dependency{
+				{GroupId: "com.h2database", ArtifactId: "h2"},
+				slf4j,
+			}},
+			{Id: "prod", Dependencies: []Dependency{
+				{GroupId: "org.postgresql", ArtifactId: "postgresql"},
+				slf4j,
+			}},
+		},
+	}
+
+	common := project.CommonProfileDependencies()
+	if len(common) != 1 {
+		t.Fatalf("expecting 1 common dependency found %d", len(common))
+	}
+	if common[0].ArtifactId != "slf4j-api" {
+		t.Errorf("artifactId does not match (expected: slf4j-api, found: %s)", common[0].ArtifactId)
+	}
+}
+
+func TestMavenProject_ProfilesAffectingDependencies(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+    </dependencies>
+    <profiles>
+        <profile>
+            <id>h2</id>
+            <dependencies>
+                <dependency>
+                    <groupId>com.h2database</groupId>
+                    <artifactId>h2</artifactId>
+                    <version>1.4.200</version>
+                </dependency>
+            </dependencies>
+        </profile>
+        <profile>
+            <id>fast</id>
+            <properties>
+                <skipTests>true</skipTests>
+            </properties>
+        </profile>
+        <profile>
+            <id>legacy-logging</id>
+            <properties>
+                <slf4j.version>1.7.22</slf4j.version>
+            </properties>
+        </profile>
+        <profile>
+            <id>neg</id>
+            <activation>
+                <property>
+                    <name>!ci</name>
+                </property>
+            </activation>
+            <dependencies>
+                <dependency>
+                    <groupId>org.example</groupId>
+                    <artifactId>local-tools</artifactId>
+                    <version>1.0</version>
+                </dependency>
+            </dependencies>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	ids := project.ProfilesAffectingDependencies()
+	expected := []string{"h2", "legacy-logging", "neg"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("profiles do not match (expected: %v, found: %v)", expected, ids)
+	}
+}
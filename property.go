@@ -0,0 +1,405 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PropertyUsedInVersions return the dependencies (managed or not) whose version reference ${key}
+func (mp *MavenProject) PropertyUsedInVersions(key string) []Dependency {
+	placeholder := "${" + key + "}"
+
+	var deps []Dependency
+	for _, dep := range mp.DependencyManagement.Dependencies {
+		if strings.Contains(dep.Version, placeholder) {
+			deps = append(deps, dep)
+		}
+	}
+	for _, dep := range mp.Dependencies {
+		if strings.Contains(dep.Version, placeholder) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// Represent an artifact declared by a section of the project: dependencies, dependencyManagement,
+// plugins or pluginManagement
+type ArtifactRef struct {
+	Artifact
+	Section string
+}
+
+// ReferencesOfProperty return the dependencies and plugins, managed or not, whose version
+// reference ${key}, with their version as declared
+func (mp *MavenProject) ReferencesOfProperty(key string) []ArtifactRef {
+	placeholder := "${" + key + "}"
+
+	var refs []ArtifactRef
+	add := func(section, groupId, artifactId, version string) {
+		if strings.Contains(version, placeholder) {
+			refs = append(refs, ArtifactRef{
+				Artifact: Artifact{GroupId: groupId, ArtifactId: artifactId, Version: version},
+				Section:  section,
+			})
+		}
+	}
+
+	for _, dep := range mp.Dependencies {
+		add("dependencies", dep.GroupId, dep.ArtifactId, dep.Version)
+	}
+	for _, dep := range mp.DependencyManagement.Dependencies {
+		add("dependencyManagement", dep.GroupId, dep.ArtifactId, dep.Version)
+	}
+	for _, plugin := range mp.Build.Plugins {
+		add("plugins", plugin.EffectiveGroupId(), plugin.ArtifactId, plugin.Version)
+	}
+	for _, plugin := range mp.Build.PluginManagement.Plugins {
+		add("pluginManagement", plugin.EffectiveGroupId(), plugin.ArtifactId, plugin.Version)
+	}
+	return refs
+}
+
+// RequiredProperties return, sorted, the keys of the properties needed to resolve the version of
+// every dependency and plugin, managed or not, following the properties referencing other ones
+// (including through built-in values such as ${project.version} referencing ${revision})
+func (mp *MavenProject) RequiredProperties() []string {
+	var pending []string
+	for _, dep := range mp.Dependencies {
+		pending = append(pending, dep.Version)
+	}
+	for _, dep := range mp.DependencyManagement.Dependencies {
+		pending = append(pending, dep.Version)
+	}
+	for _, plugin := range mp.Build.Plugins {
+		pending = append(pending, plugin.Version)
+	}
+	for _, plugin := range mp.Build.PluginManagement.Plugins {
+		pending = append(pending, plugin.Version)
+	}
+
+	// the built-in values of the project take precedence over the properties
+	builtins := *mp
+	builtins.Properties = nil
+
+	required := map[string]bool{}
+	visited := map[string]bool{}
+	for len(pending) > 0 {
+		value := pending[0]
+		pending = pending[1:]
+		for _, key := range placeholderKeys(value) {
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			resolved, exist := mp.lookupProperty(key)
+			if !exist {
+				continue
+			}
+			_, declared := mp.Properties[key]
+			if _, builtin := builtins.lookupProperty(key); declared && !builtin {
+				required[key] = true
+			}
+			if key == "project.version" || key == "pom.version" || key == "version" {
+				// the CI friendly properties are already resolved by EffectiveVersion
+				resolved = mp.Version
+				if resolved == "" {
+					resolved = mp.Parent.Version
+				}
+			}
+			pending = append(pending, resolved)
+		}
+	}
+
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// placeholderKeys return the keys of the ${key} placeholders of value, in order
+func placeholderKeys(value string) []string {
+	var keys []string
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			return keys
+		}
+		end := strings.Index(value[start:], "}")
+		if end == -1 {
+			return keys
+		}
+		keys = append(keys, value[start+2:start+end])
+		value = value[start+end+1:]
+	}
+}
+
+// VersionPinningStats count the dependency versions (managed or not) declared through a ${} property
+// and the ones declared as a literal. Dependencies without version are not counted.
+func (mp *MavenProject) VersionPinningStats() (viaProperty, viaLiteral int) {
+	count := func(deps []Dependency) {
+		for _, dep := range deps {
+			version := strings.TrimSpace(dep.Version)
+			if version == "" {
+				continue
+			}
+			if strings.Contains(version, "${") {
+				viaProperty++
+			} else {
+				viaLiteral++
+			}
+		}
+	}
+
+	count(mp.DependencyManagement.Dependencies)
+	count(mp.Dependencies)
+	return viaProperty, viaLiteral
+}
+
+// AllPropertyKeys return, sorted, the keys of the properties defined by the project and by
+// every POM of its parent chain, located using resolver
+func (mp *MavenProject) AllPropertyKeys(resolver ParentResolver) ([]string, error) {
+	effective, err := mp.EffectivePOM(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(effective.Properties))
+	for key := range effective.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// maximum number of nested placeholders resolved by Interpolate (guard against cycles)
+const maxInterpolationDepth = 16
+
+// Interpolate replace the ${key} placeholders in value by the matching project property or
+// built-in project value (project.groupId, project.artifactId, project.version, project.parent.version, ...).
+// The ${settings.*} placeholders are resolved only on projects returned by ApplyProfiles with settings.
+// Unknown placeholders are left untouched.
+func (mp *MavenProject) Interpolate(value string) string {
+	return interpolate(value, mp.lookupProperty)
+}
+
+// InterpolateContext replace the ${key} placeholders in value like Interpolate, resolving in
+// addition ${settings.localRepository} and ${settings.offline} from the settings of ctx when
+// supplied. Other settings.* placeholders are left untouched.
+func (mp *MavenProject) InterpolateContext(value string, ctx ActivationContext) string {
+	project := *mp
+	if ctx.Settings != nil {
+		project.settings = ctx.Settings
+	}
+	return project.Interpolate(value)
+}
+
+// lookupProperty return the value of given property key, looking at built-in values first
+func (mp *MavenProject) lookupProperty(key string) (string, bool) {
+	switch key {
+	case "project.groupId", "pom.groupId":
+		return mp.EffectiveGroupId(), true
+	case "project.artifactId", "pom.artifactId":
+		return mp.ArtifactId, true
+	case "project.version", "pom.version", "version":
+		return mp.EffectiveVersion(), true
+	case "project.packaging":
+		if mp.Packaging == "" {
+			return "jar", true
+		}
+		return mp.Packaging, true
+	case "project.name":
+		return mp.Name, true
+	case "project.description":
+		return mp.Description.Text(), true
+	case "project.parent.groupId":
+		if mp.Parent.ArtifactId != "" {
+			return mp.Parent.GroupId, true
+		}
+	case "project.parent.artifactId":
+		if mp.Parent.ArtifactId != "" {
+			return mp.Parent.ArtifactId, true
+		}
+	case "project.parent.version":
+		if mp.Parent.ArtifactId != "" {
+			return mp.Parent.Version, true
+		}
+	case "settings.localRepository":
+		if mp.settings != nil {
+			return mp.settings.LocalRepositoryPath(), true
+		}
+	case "settings.offline":
+		if mp.settings != nil {
+			return strconv.FormatBool(bool(mp.settings.Offline)), true
+		}
+	}
+
+	value, exist := mp.Properties[key]
+	return value, exist
+}
+
+// interpolate resolve the nested ${key} placeholders of value using lookup, up to maxInterpolationDepth
+func interpolate(value string, lookup func(string) (string, bool)) string {
+	for depth := 0; depth < maxInterpolationDepth && strings.Contains(value, "${"); depth++ {
+		resolved := interpolateOnce(value, lookup)
+		if resolved == value {
+			break
+		}
+		value = resolved
+	}
+	return value
+}
+
+// interpolateOnce replace each ${key} of value resolvable using lookup
+func interpolateOnce(value string, lookup func(string) (string, bool)) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(value[start:], "}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		sb.WriteString(value[:start])
+		if resolved, exist := lookup(value[start+2 : end]); exist {
+			sb.WriteString(resolved)
+		} else {
+			sb.WriteString(value[start : end+1])
+		}
+		value = value[end+1:]
+	}
+	sb.WriteString(value)
+	return sb.String()
+}
+
+// RenameProperty rename the oldKey property to newKey and rewrite every ${oldKey} reference of
+// the project to ${newKey}, returning the number of references updated. Nothing is done if
+// newKey is already defined.
+func (mp *MavenProject) RenameProperty(oldKey, newKey string) int {
+	if _, exist := mp.Properties[newKey]; exist || oldKey == newKey {
+		return 0
+	}
+
+	if value, exist := mp.Properties[oldKey]; exist {
+		delete(mp.Properties, oldKey)
+		mp.Properties[newKey] = value
+	}
+
+	oldRef, newRef := "${"+oldKey+"}", "${"+newKey+"}"
+	count := 0
+	walkStrings(reflect.ValueOf(mp).Elem(), func(value string) string {
+		count += strings.Count(value, oldRef)
+		return strings.Replace(value, oldRef, newRef, -1)
+	})
+	return count
+}
+
+var xmlNameType = reflect.TypeOf(xml.Name{})
+
+var configType = reflect.TypeOf(Config{})
+
+// walkStrings replace every string reachable from v (struct fields, slices, map values) by fn(value)
+func walkStrings(v reflect.Value, fn func(string) string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkStrings(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		if v.Type() == xmlNameType {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				walkStrings(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkStrings(v.Index(i), fn)
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			value := v.MapIndex(key).String()
+			if replaced := fn(value); replaced != value {
+				v.SetMapIndex(key, reflect.ValueOf(replaced).Convert(v.Type().Elem()))
+			}
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(fn(v.String()))
+		}
+	}
+}
+
+// deepCopy return a copy of v sharing no pointer, slice or map reachable through exported fields
+// with it, so that the copy can be modified with walkStrings
+func deepCopy(v reflect.Value) reflect.Value {
+	copied := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			copied.Set(reflect.New(v.Type().Elem()))
+			copied.Elem().Set(deepCopy(v.Elem()))
+		}
+	case reflect.Struct:
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				copied.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			copied.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				copied.Index(i).Set(deepCopy(v.Index(i)))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			copied.Set(reflect.MakeMap(v.Type()))
+			for _, key := range v.MapKeys() {
+				copied.SetMapIndex(key, deepCopy(v.MapIndex(key)))
+			}
+		}
+	default:
+		copied.Set(v)
+	}
+	return copied
+}
@@ -0,0 +1,430 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMavenProject_PropertyUsedInVersions(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <slf4j.version>1.7.22</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j.extra</groupId>
+            <artifactId>slf4j-extra</artifactId>
+            <version>${slf4j.version.extra}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	deps := project.PropertyUsedInVersions("slf4j.version")
+	if len(deps) != 1 {
+		t.Fatalf("expecting 1 dependency found %d", len(deps))
+	}
+	if deps[0].ArtifactId != "slf4j-api" {
+		t.Errorf("artifactId does not match (expected: slf4j-api, found: %s)", deps[0].ArtifactId)
+	}
+}
+
+func TestMavenProject_ReferencesOfProperty(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <kotlin.version>1.4.10</kotlin.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.jetbrains.kotlin</groupId>
+            <artifactId>kotlin-stdlib</artifactId>
+            <version>${kotlin.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.jetbrains.kotlin</groupId>
+                <artifactId>kotlin-maven-plugin</artifactId>
+                <version>${kotlin.version}</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	refs := project.ReferencesOfProperty("kotlin.version")
+	expected := []ArtifactRef{
+		{Artifact: Artifact{GroupId: "org.jetbrains.kotlin", ArtifactId: "kotlin-stdlib", Version: "${kotlin.version}"}, Section: "dependencies"},
+		{Artifact: Artifact{GroupId: "org.jetbrains.kotlin", ArtifactId: "kotlin-maven-plugin", Version: "${kotlin.version}"}, Section: "plugins"},
+	}
+	if !reflect.DeepEqual(refs, expected) {
+		t.Errorf("references do not match (expected: %v, found: %v)", expected, refs)
+	}
+}
+
+func TestMavenProject_RequiredProperties(t *testing.T) {
+	pomStr := `
+<project>
+    <version>${revision}</version>
+    <properties>
+        <revision>1.0.0</revision>
+        <jackson.major>2.12</jackson.major>
+        <jackson.version>${jackson.major}.3</jackson.version>
+        <compiler.version>3.8.1</compiler.version>
+        <unused.version>1.0</unused.version>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.fasterxml.jackson.core</groupId>
+                <artifactId>jackson-databind</artifactId>
+                <version>${jackson.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>core</artifactId>
+            <version>${project.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>legacy</artifactId>
+            <version>${undefined.version}</version>
+        </dependency>
+    </dependencies>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>${compiler.version}</version>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+    </build>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	expected := []string{"compiler.version", "jackson.major", "jackson.version", "revision"}
+	if keys := project.RequiredProperties(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("required properties does not match (expected: %v, found: %v)", expected, keys)
+	}
+
+	child := MavenProject{
+		Parent:       Parent{GroupId: "com.example", ArtifactId: "parent", Version: "2.0"},
+		ArtifactId:   "child",
+		Dependencies: []Dependency{{GroupId: "com.example", ArtifactId: "core", Version: "${project.parent.version}"}},
+	}
+	if keys := child.RequiredProperties(); len(keys) != 0 {
+		t.Errorf("expecting no required property, found %v", keys)
+	}
+}
+
+func TestMavenProject_VersionPinningStats(t *testing.T) {
+	project := MavenProject{
+		DependencyManagement: DependencyManagement{Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "${slf4j.version}"},
+		}},
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api"},
+			{GroupId: "com.fasterxml.jackson.core", ArtifactId: "jackson-databind", Version: "${jackson.version}"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+			{GroupId: "com.google.guava", ArtifactId: "guava", Version: "30.1-jre"},
+			{GroupId: "org.example", ArtifactId: "core", Version: "${project.version}"},
+		},
+	}
+
+	viaProperty, viaLiteral := project.VersionPinningStats()
+	if viaProperty != 3 {
+		t.Errorf("versions via property does not match (expected: 3, found: %d)", viaProperty)
+	}
+	if viaLiteral != 2 {
+		t.Errorf("versions via literal does not match (expected: 2, found: %d)", viaLiteral)
+	}
+}
+
+func TestMavenProject_AllPropertyKeys(t *testing.T) {
+	resolver := mapResolver{
+		"com.example:parent:1.0": `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+    </properties>
+</project>`,
+	}
+
+	pomStr := `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <properties>
+        <slf4j.version>1.7.22</slf4j.version>
+        <jackson.version>2.10.0</jackson.version>
+    </properties>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	keys, err := project.AllPropertyKeys(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"jackson.version", "project.build.sourceEncoding", "slf4j.version"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("property keys do not match (expected: %v, found: %v)", expected, keys)
+	}
+}
+
+func TestMavenProject_Interpolate(t *testing.T) {
+	project := MavenProject{
+		GroupId:    "com.example",
+		ArtifactId: "my-app",
+		Version:    "1.0.0",
+		Properties: Properties{
+			"base.version": "2.0",
+			"lib.version":  "${base.version}.1",
+			"cycle":        "${cycle}",
+		},
+	}
+
+	tests := map[string]string{
+		"${lib.version}": "2.0.1",
+		"${project.artifactId}-${project.version}": "my-app-1.0.0",
+		"${unknown}": "${unknown}",
+		"${cycle}":   "${cycle}",
+	}
+	for value, expected := range tests {
+		if resolved := project.Interpolate(value); resolved != expected {
+			t.Errorf("interpolation of %s does not match (expected: %s, found: %s)", value, expected, resolved)
+		}
+	}
+}
+
+func TestMavenProject_Interpolate_Parent(t *testing.T) {
+	pomStr := `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>2.1.0</version>
+    </parent>
+    <artifactId>app</artifactId>
+    <version>1.0.0</version>
+    <dependencies>
+        <dependency>
+            <groupId>${project.parent.groupId}</groupId>
+            <artifactId>core</artifactId>
+            <version>${project.parent.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	dep := project.ResolvedDependencies()[0]
+	if dep.GroupId != "com.example" || dep.Version != "2.1.0" {
+		t.Errorf("dependency does not match (expected: com.example:core:2.1.0, found: %s:%s:%s)", dep.GroupId, dep.ArtifactId, dep.Version)
+	}
+	if artifactId := project.Interpolate("${project.parent.artifactId}"); artifactId != "parent" {
+		t.Errorf("parent artifactId does not match (expected: parent, found: %s)", artifactId)
+	}
+
+	orphan := MavenProject{ArtifactId: "app"}
+	if version := orphan.Interpolate("${project.parent.version}"); version != "${project.parent.version}" {
+		t.Errorf("expecting the placeholder to stay unresolved without a parent, found: %s", version)
+	}
+}
+
+func TestMavenProject_InterpolateContext(t *testing.T) {
+	project := MavenProject{
+		Properties: Properties{
+			"bundle.dir": "${settings.localRepository}/bundles",
+		},
+	}
+	ctx := ActivationContext{Settings: &Settings{LocalRepository: "/opt/m2/repository", Offline: true}}
+
+	tests := map[string]string{
+		"${bundle.dir}":               "/opt/m2/repository/bundles",
+		"${settings.offline}":         "true",
+		"${settings.interactiveMode}": "${settings.interactiveMode}",
+	}
+	for value, expected := range tests {
+		if resolved := project.InterpolateContext(value, ctx); resolved != expected {
+			t.Errorf("interpolation of %s does not match (expected: %s, found: %s)", value, expected, resolved)
+		}
+	}
+
+	if resolved := project.InterpolateContext("${bundle.dir}", ActivationContext{}); resolved != "${settings.localRepository}/bundles" {
+		t.Errorf("interpolation without settings does not match (expected: ${settings.localRepository}/bundles, found: %s)", resolved)
+	}
+}
+
+func TestMavenProject_DirectArtifactGAVs_Settings(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1.0</version>
+    <properties>
+        <stub.version>1.0-offline-${settings.offline}</stub.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>stub</artifactId>
+            <version>${stub.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	ctx := ActivationContext{Settings: &Settings{Offline: true}}
+	gavs, err := project.DirectArtifactGAVs(ctx, mapResolver{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"com.example:stub:1.0-offline-true"}
+	if !reflect.DeepEqual(gavs, expected) {
+		t.Errorf("gavs do not match (expected: %v, found: %v)", expected, gavs)
+	}
+
+	if version := project.ApplyProfiles(ctx).ResolvedDependencies()[0].Version; version != "1.0-offline-true" {
+		t.Errorf("version does not match (expected: 1.0-offline-true, found: %s)", version)
+	}
+	if version := project.ResolvedDependencies()[0].Version; version != "1.0-offline-${settings.offline}" {
+		t.Errorf("version without settings does not match (expected: 1.0-offline-${settings.offline}, found: %s)", version)
+	}
+}
+
+func TestMavenProject_RenameProperty(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <slf4j>1.7.22</slf4j>
+        <logback.version>${slf4j}</logback.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j}</version>
+        </dependency>
+    </dependencies>
+    <profiles>
+        <profile>
+            <id>legacy</id>
+            <build>
+                <plugins>
+                    <plugin>
+                        <artifactId>slf4j-maven-plugin</artifactId>
+                        <version>${slf4j}</version>
+                    </plugin>
+                </plugins>
+            </build>
+        </profile>
+    </profiles>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if count := project.RenameProperty("slf4j", "logback.version"); count != 0 {
+		t.Errorf("expecting no rename when the new key exists, found %d", count)
+	}
+
+	count := project.RenameProperty("slf4j", "slf4j.version")
+	if count != 3 {
+		t.Errorf("expecting 3 references updated found %d", count)
+	}
+	if _, exist := project.Properties["slf4j"]; exist {
+		t.Error("expecting slf4j property to be removed")
+	}
+	if project.Properties["slf4j.version"] != "1.7.22" {
+		t.Errorf("slf4j.version does not match (expected: 1.7.22, found: %s)", project.Properties["slf4j.version"])
+	}
+	if project.Properties["logback.version"] != "${slf4j.version}" {
+		t.Errorf("logback.version does not match (expected: ${slf4j.version}, found: %s)", project.Properties["logback.version"])
+	}
+	if project.Dependencies[0].Version != "${slf4j.version}" {
+		t.Errorf("version does not match (expected: ${slf4j.version}, found: %s)", project.Dependencies[0].Version)
+	}
+	if project.Profiles[0].Build.Plugins[0].Version != "${slf4j.version}" {
+		t.Errorf("plugin version does not match (expected: ${slf4j.version}, found: %s)", project.Profiles[0].Build.Plugins[0].Version)
+	}
+}
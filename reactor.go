@@ -0,0 +1,262 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Represent a dependency required at versions that cannot be satisfied by a single version
+// across the modules of a reactor
+type ConvergenceIssue struct {
+	GroupId    string
+	ArtifactId string
+	// The version (or range) required, keyed by module groupId:artifactId
+	Versions map[string]string
+}
+
+// ReactorConvergence report the dependencies required with incompatible versions by the given
+// modules: two different pinned versions, or a pinned version outside of a range required by
+// another module.
+func ReactorConvergence(modules []*MavenProject) []ConvergenceIssue {
+	var keys []string
+	requirements := map[string]map[string]string{}
+	for _, module := range modules {
+		moduleKey := module.EffectiveGroupId() + ":" + module.ArtifactId
+		for _, dep := range module.ResolvedDependencies() {
+			if dep.Version == "" {
+				continue
+			}
+			key := dep.GroupId + ":" + dep.ArtifactId
+			if _, exist := requirements[key]; !exist {
+				keys = append(keys, key)
+				requirements[key] = map[string]string{}
+			}
+			requirements[key][moduleKey] = dep.Version
+		}
+	}
+	sort.Strings(keys)
+
+	var issues []ConvergenceIssue
+	for _, key := range keys {
+		if converges(requirements[key]) {
+			continue
+		}
+		dep := splitCoordinates(key)
+		issues = append(issues, ConvergenceIssue{
+			GroupId:    dep.GroupId,
+			ArtifactId: dep.ArtifactId,
+			Versions:   requirements[key],
+		})
+	}
+	return issues
+}
+
+// converges return true if a single version can satisfy every requirement
+func converges(requirements map[string]string) bool {
+	var pins []string
+	var ranges []VersionRange
+	for _, spec := range requirements {
+		vr, err := ParseVersionRange(spec)
+		if err != nil {
+			return false
+		}
+		if vr.IsRange() {
+			ranges = append(ranges, vr)
+		} else {
+			pins = append(pins, vr.Recommended)
+		}
+	}
+
+	for _, pin := range pins {
+		if CompareVersions(pin, pins[0]) != 0 {
+			return false
+		}
+		for _, vr := range ranges {
+			if !vr.Contains(pin) {
+				return false
+			}
+		}
+	}
+	if len(pins) > 0 || len(ranges) == 0 {
+		return true
+	}
+
+	// without a pin, the ranges must share at least one version
+	common := ranges[0].Restrictions
+	for _, vr := range ranges[1:] {
+		var next []Restriction
+		for _, a := range common {
+			for _, b := range vr.Restrictions {
+				if r, ok := a.intersect(b); ok {
+					next = append(next, r)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return false
+		}
+		common = next
+	}
+	return true
+}
+
+// splitCoordinates build a dependency from groupId:artifactId[:version] coordinates
+func splitCoordinates(coordinates string) Dependency {
+	var dep Dependency
+	parts := append(strings.SplitN(coordinates, ":", 3), "", "")
+	dep.GroupId, dep.ArtifactId, dep.Version = parts[0], parts[1], parts[2]
+	return dep
+}
+
+// ParseTree walk root and parse every pom.xml file found, returning the projects and the
+// parsing errors keyed by file path. Hidden directories and target/ build outputs are skipped.
+func ParseTree(root string) (map[string]*MavenProject, map[string]error) {
+	projects := map[string]*MavenProject{}
+	errs := map[string]error{}
+
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs[path] = err
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "target") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "pom.xml" {
+			return nil
+		}
+
+		project, err := Parse(path)
+		if err != nil {
+			errs[path] = err
+		} else {
+			projects[path] = project
+		}
+		return nil
+	})
+	if walkErr != nil {
+		errs[root] = walkErr
+	}
+
+	return projects, errs
+}
+
+// SameArtifactCoordinates return true if both projects publish the same effective
+// groupId:artifactId:version, which would make one release overwrite the other
+func SameArtifactCoordinates(a, b *MavenProject) bool {
+	return a.Interpolate(a.EffectiveGroupId()) == b.Interpolate(b.EffectiveGroupId()) &&
+		a.Interpolate(a.ArtifactId) == b.Interpolate(b.ArtifactId) &&
+		a.Interpolate(a.EffectiveVersion()) == b.Interpolate(b.EffectiveVersion())
+}
+
+// reactorResolver resolve the POMs of the reactor modules from memory and cache the ones located
+// by the wrapped resolver, so that parents shared by several modules are only resolved once
+type reactorResolver struct {
+	resolver ParentResolver
+	projects map[string]*MavenProject
+}
+
+func (r *reactorResolver) Resolve(groupId, artifactId, version string) (*MavenProject, error) {
+	key := groupId + ":" + artifactId + ":" + version
+	if project, exist := r.projects[key]; exist {
+		return project, nil
+	}
+	if r.resolver == nil {
+		return nil, fmt.Errorf("can't resolve %s, not part of the reactor", key)
+	}
+
+	project, err := resolvePOM(r.resolver, groupId, artifactId, version)
+	if err != nil {
+		return nil, err
+	}
+	r.projects[key] = project
+	return project, nil
+}
+
+// EffectiveReactor return the effective POM of the root project (a pom.xml file or the directory
+// holding it) and of every module it aggregates, recursively, keyed by file path. Each module has
+// the profiles active in given context applied, its parent chain merged and its imported BOMs
+// resolved. Parents are looked up at their relativePath first. Parents not found there and BOMs are
+// looked up among the reactor modules, then using resolver (which may be nil for a self-contained
+// reactor), each of them being resolved once.
+func EffectiveReactor(root string, resolver ParentResolver, ctx ActivationContext) (map[string]*MavenProject, error) {
+	if info, err := os.Stat(root); err == nil && info.IsDir() {
+		root = filepath.Join(root, "pom.xml")
+	}
+
+	var paths []string
+	parsed := map[string]*MavenProject{}
+	pending := []string{filepath.Clean(root)}
+	for len(pending) > 0 {
+		path := pending[0]
+		pending = pending[1:]
+		if _, exist := parsed[path]; exist {
+			continue
+		}
+
+		project, err := Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		parsed[path] = project
+
+		for _, module := range project.EffectiveModules(ctx) {
+			modulePath := filepath.Join(filepath.Dir(path), filepath.FromSlash(project.Interpolate(module)))
+			if info, err := os.Stat(modulePath); err == nil && info.IsDir() {
+				modulePath = filepath.Join(modulePath, "pom.xml")
+			}
+			pending = append(pending, modulePath)
+		}
+	}
+
+	cache := &reactorResolver{resolver: resolver, projects: map[string]*MavenProject{}}
+	for _, path := range paths {
+		project := parsed[path]
+		key := project.Interpolate(project.EffectiveGroupId()) + ":" + project.Interpolate(project.ArtifactId) + ":" +
+			project.Interpolate(project.EffectiveVersion())
+		cache.projects[key] = project
+	}
+
+	effective := map[string]*MavenProject{}
+	for _, path := range paths {
+		project, err := (&ParsedFile{Project: parsed[path], Path: path}).EffectivePOM(ctx, cache)
+		if err != nil {
+			return nil, fmt.Errorf("can't resolve effective pom of %s, %v", path, err)
+		}
+		if err := project.ResolveImportedBOMs(cache); err != nil {
+			return nil, fmt.Errorf("can't resolve effective pom of %s, %v", path, err)
+		}
+		effective[path] = project
+	}
+	return effective, nil
+}
@@ -0,0 +1,319 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReactorConvergence(t *testing.T) {
+	moduleA := &MavenProject{
+		GroupId:    "com.example",
+		ArtifactId: "module-a",
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "[1,2)"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+		},
+	}
+	moduleB := &MavenProject{
+		GroupId:    "com.example",
+		ArtifactId: "module-b",
+		Dependencies: []Dependency{
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "2.0"},
+			{GroupId: "junit", ArtifactId: "junit", Version: "4.12"},
+		},
+	}
+
+	issues := ReactorConvergence([]*MavenProject{moduleA, moduleB})
+	if len(issues) != 1 {
+		t.Fatalf("expecting 1 convergence issue found %d", len(issues))
+	}
+	if issues[0].ArtifactId != "slf4j-api" {
+		t.Errorf("artifactId does not match (expected: slf4j-api, found: %s)", issues[0].ArtifactId)
+	}
+	if issues[0].Versions["com.example:module-a"] != "[1,2)" {
+		t.Errorf("module-a version does not match (expected: [1,2), found: %s)", issues[0].Versions["com.example:module-a"])
+	}
+
+	// a pin within the range converges
+	moduleB.Dependencies[0].Version = "1.5"
+	if issues := ReactorConvergence([]*MavenProject{moduleA, moduleB}); len(issues) != 0 {
+		t.Errorf("expecting no convergence issue found %d", len(issues))
+	}
+
+	// without a pin, the ranges must overlap
+	tests := map[string]int{
+		"[3,4)":       1,
+		"[2,3)":       1,
+		"[1.5,3)":     0,
+		"(,1],[3,4)":  0,
+		"[0.5,1)":     1,
+		"[1.9,1.9.1]": 0,
+	}
+	for version, expected := range tests {
+		moduleB.Dependencies[0].Version = version
+		if issues := ReactorConvergence([]*MavenProject{moduleA, moduleB}); len(issues) != expected {
+			t.Errorf("convergence issues of [1,2) and %s does not match (expected: %d, found: %d)", version, expected, len(issues))
+		}
+	}
+}
+
+func TestParseTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rootPom := writeFile(t, dir, "pom.xml", "<project><artifactId>root</artifactId></project>")
+	corePom := writeFile(t, dir, "core/pom.xml", "<project><artifactId>core</artifactId></project>")
+	apiPom := writeFile(t, dir, "core/api/pom.xml", "<project><artifactId>api</artifactId></project>")
+	brokenPom := writeFile(t, dir, "broken/pom.xml", "<project><artifactId>broken</artifactId>")
+	writeFile(t, dir, "core/target/classes/META-INF/maven/pom.xml", "<project><artifactId>output</artifactId></project>")
+	writeFile(t, dir, ".git/pom.xml", "<project><artifactId>hidden</artifactId></project>")
+	writeFile(t, dir, "core/README.md", "# core")
+
+	projects, errs := ParseTree(dir)
+	if len(projects) != 3 {
+		t.Errorf("expecting 3 projects found %d", len(projects))
+	}
+	for path, artifactId := range map[string]string{rootPom: "root", corePom: "core", apiPom: "api"} {
+		if project, exist := projects[path]; !exist || project.ArtifactId != artifactId {
+			t.Errorf("expecting %s to be parsed as %s", path, artifactId)
+		}
+	}
+	if len(errs) != 1 || errs[brokenPom] == nil {
+		t.Errorf("expecting an error for %s, found %v", brokenPom, errs)
+	}
+}
+
+func TestSameArtifactCoordinates(t *testing.T) {
+	a := &MavenProject{GroupId: "com.example", ArtifactId: "core", Version: "1.0"}
+	b := &MavenProject{
+		Parent:     Parent{GroupId: "com.example", ArtifactId: "parent", Version: "1.0"},
+		ArtifactId: "${name}",
+		Properties: Properties{"name": "core"},
+	}
+	if !SameArtifactCoordinates(a, b) {
+		t.Errorf("expecting %s and %s to share coordinates", a.ArtifactId, b.ArtifactId)
+	}
+
+	c := &MavenProject{GroupId: "com.example", ArtifactId: "core", Version: "1.1"}
+	if SameArtifactCoordinates(a, c) {
+		t.Error("expecting projects with different versions not to share coordinates")
+	}
+}
+
+// countingResolver count the resolutions delegated to the wrapped resolver
+type countingResolver struct {
+	resolver ParentResolver
+	count    int
+}
+
+func (r *countingResolver) Resolve(groupId, artifactId, version string) (*MavenProject, error) {
+	r.count++
+	return r.resolver.Resolve(groupId, artifactId, version)
+}
+
+func TestEffectiveReactor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rootPom := writeFile(t, dir, "pom.xml", `
+<project>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>corporate</artifactId>
+        <version>3</version>
+    </parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <modules>
+        <module>core</module>
+        <module>app</module>
+    </modules>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.example</groupId>
+                <artifactId>core</artifactId>
+                <version>${project.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`)
+	corePom := writeFile(t, dir, "core/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>core</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+    </dependencies>
+</project>`)
+	appPom := writeFile(t, dir, "app/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1.0</version>
+    </parent>
+    <artifactId>app</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>core</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-simple</artifactId>
+        </dependency>
+    </dependencies>
+</project>`)
+
+	resolver := &countingResolver{resolver: mapResolver{
+		"org.example:corporate:3": `
+<project>
+    <groupId>org.example</groupId>
+    <artifactId>corporate</artifactId>
+    <version>3</version>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>1.7.30</version>
+            </dependency>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-simple</artifactId>
+                <version>1.7.30</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`,
+	}}
+
+	projects, err := EffectiveReactor(dir, resolver, ActivationContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 3 || projects[rootPom] == nil {
+		t.Fatalf("expecting the root and 2 modules, found %v", projects)
+	}
+
+	for path, expected := range map[string][]string{
+		corePom: {"org.slf4j:slf4j-api:1.7.30"},
+		appPom:  {"com.example:core:1.0", "org.slf4j:slf4j-simple:1.7.30"},
+	} {
+		var gavs []string
+		for _, dep := range projects[path].ResolvedDependencies() {
+			gavs = append(gavs, dep.GroupId+":"+dep.ArtifactId+":"+dep.Version)
+		}
+		if !reflect.DeepEqual(gavs, expected) {
+			t.Errorf("dependencies of %s does not match (expected: %v, found: %v)", path, expected, gavs)
+		}
+	}
+	if resolver.count != 1 {
+		t.Errorf("expecting the corporate parent to be resolved once, found %d resolutions", resolver.count)
+	}
+
+	if _, err := EffectiveReactor(dir, nil, ActivationContext{}); err == nil {
+		t.Error("expecting an error when the corporate parent can't be resolved")
+	}
+}
+
+func TestEffectiveReactor_RelativePathParent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "pom.xml", `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>aggregator</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <modules>
+        <module>app</module>
+    </modules>
+</project>`)
+	// the parent is not a module of the reactor
+	writeFile(t, dir, "parent/pom.xml", `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+    <packaging>pom</packaging>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>1.7.30</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`)
+	appPom := writeFile(t, dir, "app/pom.xml", `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0</version>
+        <relativePath>../parent</relativePath>
+    </parent>
+    <artifactId>app</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+    </dependencies>
+</project>`)
+
+	projects, err := EffectiveReactor(dir, nil, ActivationContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := projects[appPom].ResolvedDependencies()
+	if len(deps) != 1 || deps[0].Version != "1.7.30" {
+		t.Errorf("expecting slf4j-api 1.7.30, found %v", deps)
+	}
+}
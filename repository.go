@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DuplicateRepositoryIDs return the ids declared more than once by the repositories or by the
+// pluginRepositories. As in maven, a repository and a pluginRepository may share an id since
+// they usually designate the same server.
+func (mp *MavenProject) DuplicateRepositoryIDs() []string {
+	var duplicates []string
+	reported := map[string]bool{}
+	check := func(ids []string) {
+		seen := map[string]bool{}
+		for _, id := range ids {
+			if seen[id] && !reported[id] {
+				reported[id] = true
+				duplicates = append(duplicates, id)
+			}
+			seen[id] = true
+		}
+	}
+
+	var ids []string
+	for _, repo := range mp.Repositories {
+		ids = append(ids, repo.Id)
+	}
+	check(ids)
+
+	ids = nil
+	for _, repo := range mp.PluginRepositories {
+		ids = append(ids, repo.Id)
+	}
+	check(ids)
+
+	return duplicates
+}
+
+// EffectiveSiteURL return the site deployment url of the project with the module path appended,
+// following maven's child inheritance of the parent site url. The module path is usually the
+// artifactId of the module. An empty string is returned when no site url is declared.
+func (mp *MavenProject) EffectiveSiteURL(modulePath string) string {
+	siteUrl := strings.TrimSpace(mp.DistributionManagement.Site.Url)
+	if siteUrl == "" {
+		return ""
+	}
+	modulePath = strings.Trim(modulePath, "/")
+	if modulePath == "" {
+		return siteUrl
+	}
+	return strings.TrimRight(siteUrl, "/") + "/" + modulePath
+}
+
+// UsesImplicitCentral return true if the build would reach the maven central repository inherited
+// from the super POM, i.e. the repositories or the pluginRepositories do not declare a repository
+// with the central id. Such a declaration overrides central, whether it points to a mirror or disables it.
+func (mp *MavenProject) UsesImplicitCentral() bool {
+	overridden := false
+	for _, repo := range mp.Repositories {
+		if strings.TrimSpace(repo.Id) == "central" {
+			overridden = true
+		}
+	}
+	if !overridden {
+		return true
+	}
+
+	for _, repo := range mp.PluginRepositories {
+		if strings.TrimSpace(repo.Id) == "central" {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckRepositories send a HEAD request to the url of every repository and pluginRepository and
+// return the failures keyed by repository id: network errors and error statuses other than 401
+// and 403, which denote a reachable repository requiring credentials. Reachable repositories are
+// not part of the result. ctx bounds the requests, and client defaults to http.DefaultClient.
+func (mp *MavenProject) CheckRepositories(ctx context.Context, client *http.Client) map[string]error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	urls := map[string]string{}
+	var ids []string
+	add := func(id, url string) {
+		if _, exist := urls[id]; !exist {
+			ids = append(ids, id)
+			urls[id] = mp.Interpolate(strings.TrimSpace(url))
+		}
+	}
+	for _, repo := range mp.Repositories {
+		add(repo.Id, repo.Url)
+	}
+	for _, repo := range mp.PluginRepositories {
+		add(repo.Id, repo.Url)
+	}
+
+	failures := map[string]error{}
+	for _, id := range ids {
+		if err := checkURL(ctx, client, urls[id]); err != nil {
+			failures[id] = err
+		}
+	}
+	return failures
+}
+
+// checkURL return an error if url cannot be reached with a HEAD request
+func checkURL(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("can't create request for %s, %v", url, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't reach %s, %v", url, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return fmt.Errorf("can't reach %s, unexpected status %s", url, resp.Status)
+	}
+	return nil
+}
+
+// RepositoryGroupPrefixes map the url fragments of well-known repositories to the groupId
+// prefixes they typically host
+var RepositoryGroupPrefixes = map[string][]string{
+	"repo.spring.io":            {"org.springframework", "io.spring"},
+	"packages.confluent.io":     {"io.confluent"},
+	"repository.jboss.org":      {"org.jboss", "org.wildfly", "org.hibernate"},
+	"maven.google.com":          {"com.android", "androidx", "com.google.android"},
+	"jitpack.io":                {"com.github"},
+	"repository.apache.org":     {"org.apache"},
+	"plugins.gradle.org/m2":     {"com.gradle", "org.gradle"},
+	"maven.pkg.jetbrains.space": {"org.jetbrains"},
+}
+
+// LikelyRepositoriesByGroup map the groupId of the dependencies to the ids of the declared
+// repositories likely to host them, according to RepositoryGroupPrefixes. This is a heuristic:
+// groups matching no repository are left out.
+func (mp *MavenProject) LikelyRepositoriesByGroup() map[string][]string {
+	likely := map[string][]string{}
+	for _, dep := range mp.ResolvedDependencies() {
+		if _, exist := likely[dep.GroupId]; exist {
+			continue
+		}
+
+		var ids []string
+		for _, repo := range mp.Repositories {
+			url := mp.Interpolate(repo.Url)
+			for fragment, prefixes := range RepositoryGroupPrefixes {
+				if strings.Contains(url, fragment) && hasGroupPrefix(dep.GroupId, prefixes) {
+					ids = append(ids, repo.Id)
+					break
+				}
+			}
+		}
+		if len(ids) > 0 {
+			likely[dep.GroupId] = ids
+		}
+	}
+	return likely
+}
+
+// PossiblyUnusedRepositories return the declared repositories hosting none of the dependency groups
+// according to RepositoryGroupPrefixes. This is advisory only: the heuristic knows nothing of the
+// transitive dependencies, and repositories whose url matches no known fragment are never reported.
+func (mp *MavenProject) PossiblyUnusedRepositories() []Repository {
+	deps := mp.ResolvedDependencies()
+
+	var unused []Repository
+	for _, repo := range mp.Repositories {
+		url := mp.Interpolate(repo.Url)
+		known, used := false, false
+		for fragment, prefixes := range RepositoryGroupPrefixes {
+			if !strings.Contains(url, fragment) {
+				continue
+			}
+			known = true
+			for _, dep := range deps {
+				if hasGroupPrefix(dep.GroupId, prefixes) {
+					used = true
+					break
+				}
+			}
+		}
+		if known && !used {
+			unused = append(unused, repo)
+		}
+	}
+	return unused
+}
+
+// hasGroupPrefix return true if groupId is one of the prefixes or a sub group of one of them
+func hasGroupPrefix(groupId string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if groupId == prefix || strings.HasPrefix(groupId, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMavenProject_DuplicateRepositoryIDs(t *testing.T) {
+	pomStr := `
+<project>
+    <repositories>
+        <repository>
+            <id>private-repository</id>
+            <url>http://localhost:8081/repository/maven-private/</url>
+        </repository>
+        <repository>
+            <id>private-repository</id>
+            <url>http://localhost:8082/repository/maven-private/</url>
+        </repository>
+        <repository>
+            <id>shared</id>
+            <url>http://localhost:8081/repository/maven-shared/</url>
+        </repository>
+    </repositories>
+    <pluginRepositories>
+        <pluginRepository>
+            <id>shared</id>
+            <url>http://localhost:8081/repository/maven-shared/</url>
+        </pluginRepository>
+    </pluginRepositories>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	ids := project.DuplicateRepositoryIDs()
+	if len(ids) != 1 || ids[0] != "private-repository" {
+		t.Errorf("duplicate ids does not match (expected: [private-repository], found: %v)", ids)
+	}
+}
+
+func TestMavenProject_EffectiveSiteURL(t *testing.T) {
+	pomStr := `
+<project>
+    <distributionManagement>
+        <site>
+            <id>website</id>
+            <url>scp://www.example.com/www/docs/project/</url>
+        </site>
+    </distributionManagement>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if project.DistributionManagement.Site.Id != "website" {
+		t.Errorf("site id does not match (expected: website, found: %s)", project.DistributionManagement.Site.Id)
+	}
+	expected := "scp://www.example.com/www/docs/project/module-a"
+	if url := project.EffectiveSiteURL("module-a"); url != expected {
+		t.Errorf("site url does not match (expected: %s, found: %s)", expected, url)
+	}
+	expected = "scp://www.example.com/www/docs/project/"
+	if url := project.EffectiveSiteURL(""); url != expected {
+		t.Errorf("site url does not match (expected: %s, found: %s)", expected, url)
+	}
+	if url := (&MavenProject{}).EffectiveSiteURL("module-a"); url != "" {
+		t.Errorf("site url should be empty, found: %s", url)
+	}
+}
+
+func TestMavenProject_UsesImplicitCentral(t *testing.T) {
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(`
+<project>
+    <repositories>
+        <repository>
+            <id>private-repository</id>
+            <url>http://localhost:8081/repository/maven-private/</url>
+        </repository>
+    </repositories>
+</project>`), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+	if !project.UsesImplicitCentral() {
+		t.Error("project without central repository should use the implicit central")
+	}
+
+	project = MavenProject{}
+	if err := xml.Unmarshal([]byte(`
+<project>
+    <repositories>
+        <repository>
+            <id>central</id>
+            <url>http://localhost:8081/repository/maven-central/</url>
+        </repository>
+    </repositories>
+    <pluginRepositories>
+        <pluginRepository>
+            <id>central</id>
+            <url>http://localhost:8081/repository/maven-central/</url>
+        </pluginRepository>
+    </pluginRepositories>
+</project>`), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+	if project.UsesImplicitCentral() {
+		t.Error("project overriding central should not use the implicit central")
+	}
+
+	project.PluginRepositories = nil
+	if !project.UsesImplicitCentral() {
+		t.Error("project without central plugin repository should use the implicit central")
+	}
+}
+
+func TestMavenProject_CheckRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method does not match (expected: HEAD, found: %s)", r.Method)
+		}
+		if r.URL.Path == "/missing/" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	project := MavenProject{
+		Repositories: []Repository{
+			{Id: "reachable", Url: server.URL + "/repository/"},
+			{Id: "missing", Url: server.URL + "/missing/"},
+			{Id: "bogus", Url: "http://127.0.0.1:0/repository/"},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	failures := project.CheckRepositories(ctx, server.Client())
+	if len(failures) != 2 {
+		t.Errorf("expecting 2 failures found %d (%v)", len(failures), failures)
+	}
+	if err, exist := failures["reachable"]; exist {
+		t.Errorf("expecting reachable repository to be reachable, found %v", err)
+	}
+	if failures["missing"] == nil {
+		t.Error("expecting an error for the missing repository")
+	}
+	if failures["bogus"] == nil {
+		t.Error("expecting an error for the bogus repository")
+	}
+}
+
+func TestMavenProject_LikelyRepositoriesByGroup(t *testing.T) {
+	project := MavenProject{
+		Repositories: []Repository{
+			{Id: "confluent", Url: "https://packages.confluent.io/maven/"},
+		},
+		Dependencies: []Dependency{
+			{GroupId: "io.confluent", ArtifactId: "kafka-avro-serializer", Version: "6.0.0"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+		},
+	}
+
+	likely := project.LikelyRepositoriesByGroup()
+	if len(likely) != 1 {
+		t.Errorf("expecting 1 group found %d (%v)", len(likely), likely)
+	}
+	if ids := likely["io.confluent"]; len(ids) != 1 || ids[0] != "confluent" {
+		t.Errorf("repositories do not match (expected: [confluent], found: %v)", ids)
+	}
+}
+
+func TestMavenProject_PossiblyUnusedRepositories(t *testing.T) {
+	project := MavenProject{
+		Repositories: []Repository{
+			{Id: "confluent", Url: "https://packages.confluent.io/maven/"},
+			{Id: "jboss", Url: "https://repository.jboss.org/nexus/content/groups/public/"},
+			{Id: "internal", Url: "https://nexus.example.com/repository/releases/"},
+		},
+		Dependencies: []Dependency{
+			{GroupId: "io.confluent", ArtifactId: "kafka-avro-serializer", Version: "6.0.0"},
+			{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+		},
+	}
+
+	unused := project.PossiblyUnusedRepositories()
+	if len(unused) != 1 {
+		t.Fatalf("expecting 1 possibly unused repository found %d (%v)", len(unused), unused)
+	}
+	if unused[0].Id != "jboss" {
+		t.Errorf("repository id does not match (expected: jboss, found: %s)", unused[0].Id)
+	}
+}
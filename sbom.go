@@ -0,0 +1,96 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Represent a software component of a bill of materials
+type Component struct {
+	// Package URL (e.g. pkg:maven/org.slf4j/slf4j-api@1.7.30)
+	PURL    string
+	Name    string
+	Version string
+	// Licenses names, only known for the project itself
+	License string
+}
+
+// Components return the bill of materials of the project: the project itself followed by its
+// resolved direct dependencies. The license of a dependency is declared by its own POM, which is
+// not available here, so it is left empty.
+func (mp *MavenProject) Components() []Component {
+	groupId := mp.Interpolate(mp.EffectiveGroupId())
+	artifactId := mp.Interpolate(mp.ArtifactId)
+	version := mp.Interpolate(mp.EffectiveVersion())
+
+	var licenses []string
+	for _, license := range mp.Licenses {
+		if name := strings.TrimSpace(license.Name); name != "" {
+			licenses = append(licenses, name)
+		}
+	}
+
+	components := []Component{{
+		PURL:    purl(groupId, artifactId, version),
+		Name:    artifactId,
+		Version: version,
+		License: strings.Join(licenses, ", "),
+	}}
+	for _, dep := range mp.ResolvedDependencies() {
+		components = append(components, Component{
+			PURL:    dep.PURL(),
+			Name:    dep.ArtifactId,
+			Version: dep.Version,
+		})
+	}
+	return components
+}
+
+// PURL return the Package URL of the dependency (e.g. pkg:maven/org.slf4j/slf4j-api@1.7.30),
+// qualified by its classifier and type when they are set. The jar type is the default and not written.
+func (d Dependency) PURL() string {
+	var qualifiers []string
+	if d.Classifier != "" {
+		qualifiers = append(qualifiers, "classifier="+url.QueryEscape(d.Classifier))
+	}
+	if d.Type != "" && d.Type != "jar" {
+		qualifiers = append(qualifiers, "type="+url.QueryEscape(d.Type))
+	}
+
+	p := purl(d.GroupId, d.ArtifactId, d.Version)
+	if len(qualifiers) > 0 {
+		p += "?" + strings.Join(qualifiers, "&")
+	}
+	return p
+}
+
+// purl return the maven Package URL of given coordinates
+func purl(groupId, artifactId, version string) string {
+	p := "pkg:maven/" + url.PathEscape(groupId) + "/" + url.PathEscape(artifactId)
+	if version != "" {
+		p += "@" + url.PathEscape(version)
+	}
+	return p
+}
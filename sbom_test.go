@@ -0,0 +1,97 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestMavenProject_Components(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.0.0</version>
+    <licenses>
+        <license>
+            <name>MIT</name>
+        </license>
+    </licenses>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	components := project.Components()
+	if len(components) != 2 {
+		t.Fatalf("expecting 2 components found %d", len(components))
+	}
+
+	expected := Component{PURL: "pkg:maven/com.example/my-app@1.0.0", Name: "my-app", Version: "1.0.0", License: "MIT"}
+	if components[0] != expected {
+		t.Errorf("component does not match (expected: %+v, found: %+v)", expected, components[0])
+	}
+	expected = Component{PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.30", Name: "slf4j-api", Version: "1.7.30"}
+	if components[1] != expected {
+		t.Errorf("component does not match (expected: %+v, found: %+v)", expected, components[1])
+	}
+}
+
+func TestDependency_PURL(t *testing.T) {
+	tests := []struct {
+		dep      Dependency
+		expected string
+	}{
+		{
+			dep:      Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30"},
+			expected: "pkg:maven/org.slf4j/slf4j-api@1.7.30",
+		},
+		{
+			dep:      Dependency{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.30", Type: "jar", Classifier: "sources"},
+			expected: "pkg:maven/org.slf4j/slf4j-api@1.7.30?classifier=sources",
+		},
+		{
+			dep:      Dependency{GroupId: "org.example", ArtifactId: "core", Version: "1.0", Type: "test-jar", Classifier: "tests"},
+			expected: "pkg:maven/org.example/core@1.0?classifier=tests&type=test-jar",
+		},
+	}
+
+	for _, test := range tests {
+		if purl := test.dep.PURL(); purl != test.expected {
+			t.Errorf("purl does not match (expected: %s, found: %s)", test.expected, purl)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Represent a maven settings.xml file
+type Settings struct {
+	XMLName         xml.Name          `xml:"settings"`
+	LocalRepository string            `xml:"localRepository"`
+	Offline         XMLBool           `xml:"offline"`
+	Servers         []Server          `xml:"servers>server"`
+	Mirrors         []Mirror          `xml:"mirrors>mirror"`
+	Profiles        []SettingsProfile `xml:"profiles>profile"`
+	ActiveProfiles  []string          `xml:"activeProfiles>activeProfile"`
+}
+
+// Represent the credentials of a server
+type Server struct {
+	Id         string `xml:"id"`
+	Username   string `xml:"username"`
+	Password   string `xml:"password"`
+	PrivateKey string `xml:"privateKey"`
+	Passphrase string `xml:"passphrase"`
+}
+
+// Represent a mirror of one or more repositories
+type Mirror struct {
+	Id       string `xml:"id"`
+	Name     string `xml:"name"`
+	Url      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// Represent a profile declared in settings.xml
+type SettingsProfile struct {
+	Id                 string             `xml:"id"`
+	Activation         Activation         `xml:"activation"`
+	Properties         Properties         `xml:"properties"`
+	Repositories       []Repository       `xml:"repositories>repository"`
+	PluginRepositories []PluginRepository `xml:"pluginRepositories>pluginRepository"`
+}
+
+// ParseSettings parse a settings.xml file and return the Settings representing it.
+func ParseSettings(settingsPath string) (*Settings, error) {
+	bytes, err := ioutil.ReadFile(settingsPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read file %s, %v", settingsPath, err)
+	}
+
+	var settings Settings
+	if err := xml.Unmarshal(bytes, &settings); err != nil {
+		return nil, fmt.Errorf("can't parse file %s, %v", settingsPath, err)
+	}
+	return &settings, nil
+}
+
+// MergeSettings combine the global settings (${maven.home}/conf/settings.xml) with the user
+// settings (~/.m2/settings.xml) as maven does: the user localRepository, servers, mirrors and
+// profiles take precedence over the global ones sharing the same id. Either settings may be nil.
+func MergeSettings(global, user *Settings) *Settings {
+	if global == nil {
+		global = &Settings{}
+	}
+	if user == nil {
+		user = &Settings{}
+	}
+
+	merged := *user
+	if merged.LocalRepository == "" {
+		merged.LocalRepository = global.LocalRepository
+	}
+	merged.Offline = user.Offline || global.Offline
+
+	merged.Servers = append([]Server{}, user.Servers...)
+	for _, server := range global.Servers {
+		overridden := false
+		for _, s := range user.Servers {
+			if s.Id == server.Id {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged.Servers = append(merged.Servers, server)
+		}
+	}
+
+	merged.Mirrors = append([]Mirror{}, user.Mirrors...)
+	for _, mirror := range global.Mirrors {
+		overridden := false
+		for _, m := range user.Mirrors {
+			if m.Id == mirror.Id {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged.Mirrors = append(merged.Mirrors, mirror)
+		}
+	}
+
+	merged.Profiles = append([]SettingsProfile{}, user.Profiles...)
+	for _, profile := range global.Profiles {
+		overridden := false
+		for _, p := range user.Profiles {
+			if p.Id == profile.Id {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged.Profiles = append(merged.Profiles, profile)
+		}
+	}
+
+	merged.ActiveProfiles = append([]string{}, user.ActiveProfiles...)
+	for _, id := range global.ActiveProfiles {
+		if !contains(merged.ActiveProfiles, id) {
+			merged.ActiveProfiles = append(merged.ActiveProfiles, id)
+		}
+	}
+
+	return &merged
+}
+
+// LocalRepositoryPath return the path of the local repository: the configured localRepository,
+// with a leading ~ or ${user.home} expanded, or the default ~/.m2/repository
+func (s *Settings) LocalRepositoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+
+	path := strings.TrimSpace(s.LocalRepository)
+	if path == "" {
+		return filepath.Join(home, ".m2", "repository")
+	}
+	for _, prefix := range []string{"~", "${user.home}"} {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || strings.HasPrefix(path, prefix+string(filepath.Separator)) {
+			return filepath.Join(home, path[len(prefix):])
+		}
+	}
+	return filepath.Clean(path)
+}
+
+// ServerFor return the server holding the credentials of the repository with given id
+func (s *Settings) ServerFor(repositoryId string) (*Server, bool) {
+	for i := range s.Servers {
+		if s.Servers[i].Id == repositoryId {
+			return &s.Servers[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,148 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mvnparser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	global, err := ParseSettings(writeFile(t, dir, "conf/settings.xml", `
+<settings>
+    <localRepository>/opt/maven/repository</localRepository>
+    <servers>
+        <server>
+            <id>releases</id>
+            <username>deployer</username>
+            <password>global</password>
+        </server>
+    </servers>
+    <mirrors>
+        <mirror>
+            <id>corporate</id>
+            <url>https://repo.example.com/maven2</url>
+            <mirrorOf>*</mirrorOf>
+        </mirror>
+    </mirrors>
+</settings>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := ParseSettings(writeFile(t, dir, ".m2/settings.xml", `
+<settings>
+    <localRepository>/home/user/.m2/custom</localRepository>
+    <servers>
+        <server>
+            <id>releases</id>
+            <username>me</username>
+            <password>secret</password>
+        </server>
+        <server>
+            <id>snapshots</id>
+            <username>me</username>
+        </server>
+    </servers>
+    <activeProfiles>
+        <activeProfile>corporate</activeProfile>
+    </activeProfiles>
+</settings>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged := MergeSettings(global, user)
+	if merged.LocalRepository != "/home/user/.m2/custom" {
+		t.Errorf("localRepository does not match (expected: /home/user/.m2/custom, found: %s)", merged.LocalRepository)
+	}
+	if len(merged.Servers) != 2 {
+		t.Fatalf("expecting 2 servers found %d", len(merged.Servers))
+	}
+	if merged.Servers[0].Id != "releases" || merged.Servers[0].Username != "me" {
+		t.Errorf("server[0] does not match (expected: releases/me, found: %s/%s)", merged.Servers[0].Id, merged.Servers[0].Username)
+	}
+	if merged.Servers[1].Id != "snapshots" {
+		t.Errorf("server[1] id does not match (expected: snapshots, found: %s)", merged.Servers[1].Id)
+	}
+	if len(merged.Mirrors) != 1 || merged.Mirrors[0].MirrorOf != "*" {
+		t.Errorf("mirrors do not match (expected: [corporate], found: %v)", merged.Mirrors)
+	}
+	if len(merged.ActiveProfiles) != 1 || merged.ActiveProfiles[0] != "corporate" {
+		t.Errorf("active profiles do not match (expected: [corporate], found: %v)", merged.ActiveProfiles)
+	}
+
+	if merged := MergeSettings(global, nil); merged.LocalRepository != "/opt/maven/repository" {
+		t.Errorf("localRepository does not match (expected: /opt/maven/repository, found: %s)", merged.LocalRepository)
+	}
+}
+
+func TestSettings_LocalRepositoryPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	tests := map[string]string{
+		"/opt/maven/repository":         "/opt/maven/repository",
+		"":                              filepath.Join(home, ".m2", "repository"),
+		"~/repository":                  filepath.Join(home, "repository"),
+		"${user.home}/.m2/custom-repo/": filepath.Join(home, ".m2", "custom-repo"),
+	}
+
+	for localRepository, expected := range tests {
+		settings := Settings{LocalRepository: localRepository}
+		if path := settings.LocalRepositoryPath(); path != expected {
+			t.Errorf("path of %q does not match (expected: %s, found: %s)", localRepository, expected, path)
+		}
+	}
+}
+
+func TestSettings_ServerFor(t *testing.T) {
+	settings := Settings{Servers: []Server{
+		{Id: "releases", Username: "deployer", Password: "secret"},
+		{Id: "snapshots", Username: "snapshot-deployer"},
+	}}
+	project := MavenProject{Repositories: []Repository{
+		{Id: "snapshots", Url: "https://repo.example.com/snapshots"},
+	}}
+
+	server, exist := settings.ServerFor(project.Repositories[0].Id)
+	if !exist {
+		t.Fatalf("expecting a server for %s", project.Repositories[0].Id)
+	}
+	if server.Username != "snapshot-deployer" {
+		t.Errorf("username does not match (expected: snapshot-deployer, found: %s)", server.Username)
+	}
+
+	if _, exist := settings.ServerFor("central"); exist {
+		t.Error("expecting no server for central")
+	}
+}
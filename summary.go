@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Summary return a concise multi-line description of the project, suitable for CLI output
+func (mp *MavenProject) Summary() string {
+	var sb strings.Builder
+
+	groupId := mp.Interpolate(mp.EffectiveGroupId())
+	version := mp.Interpolate(mp.EffectiveVersion())
+	packaging := mp.Packaging
+	if packaging == "" {
+		packaging = "jar"
+	}
+
+	fmt.Fprintf(&sb, "%s:%s:%s\n", groupId, mp.ArtifactId, version)
+	if mp.Name != "" {
+		fmt.Fprintf(&sb, "├─ name: %s\n", mp.Name)
+	}
+	fmt.Fprintf(&sb, "├─ packaging: %s\n", packaging)
+	if mp.Parent.ArtifactId != "" {
+		fmt.Fprintf(&sb, "├─ parent: %s:%s:%s\n", mp.Parent.GroupId, mp.Parent.ArtifactId, mp.Parent.Version)
+	}
+	fmt.Fprintf(&sb, "├─ dependencies: %d\n", len(mp.Dependencies))
+	fmt.Fprintf(&sb, "├─ plugins: %d\n", len(mp.Build.Plugins))
+	fmt.Fprintf(&sb, "├─ modules: %d\n", len(mp.Modules))
+	fmt.Fprintf(&sb, "└─ repositories: %d\n", len(mp.Repositories))
+	for i, repo := range mp.Repositories {
+		prefix := "├─"
+		if i == len(mp.Repositories)-1 {
+			prefix = "└─"
+		}
+		fmt.Fprintf(&sb, "   %s %s (%s)\n", prefix, repo.Id, repo.Url)
+	}
+
+	return sb.String()
+}
+
+// ToDOT return a Graphviz DOT digraph of the project and its direct dependencies, each edge
+// being labeled with the dependency scope
+func (mp *MavenProject) ToDOT() string {
+	var sb strings.Builder
+
+	root := fmt.Sprintf("%s:%s:%s", mp.Interpolate(mp.EffectiveGroupId()), mp.ArtifactId, mp.Interpolate(mp.EffectiveVersion()))
+	sb.WriteString("digraph dependencies {\n")
+	fmt.Fprintf(&sb, "    %q;\n", root)
+	for _, dep := range mp.ResolvedDependencies() {
+		node := fmt.Sprintf("%s:%s:%s", dep.GroupId, dep.ArtifactId, dep.Version)
+		fmt.Fprintf(&sb, "    %q -> %q [label=%q];\n", root, node, dep.EffectiveScope())
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// DependenciesText return the resolved dependencies as sorted groupId:artifactId:version [scope]
+// lines, a flat export suitable for committing and diffing
+func (mp *MavenProject) DependenciesText() string {
+	var lines []string
+	for _, dep := range mp.ResolvedDependencies() {
+		lines = append(lines, fmt.Sprintf("%s:%s:%s [%s]\n", dep.GroupId, dep.ArtifactId, dep.Version, dep.EffectiveScope()))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "")
+}
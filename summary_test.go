@@ -0,0 +1,194 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestMavenProject_Summary(t *testing.T) {
+	pomStr := `
+<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>2.0.0</version>
+    </parent>
+    <artifactId>my-app</artifactId>
+    <packaging>pom</packaging>
+    <modules>
+        <module>core</module>
+        <module>web</module>
+    </modules>
+    <repositories>
+        <repository>
+            <id>private-repository</id>
+            <url>http://localhost:8081/repository/maven-private/</url>
+        </repository>
+    </repositories>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	summary := project.Summary()
+	expected := []string{
+		"com.example:my-app:2.0.0",
+		"packaging: pom",
+		"parent: com.example:parent:2.0.0",
+		"dependencies: 1",
+		"plugins: 0",
+		"modules: 2",
+		"private-repository (http://localhost:8081/repository/maven-private/)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(summary, e) {
+			t.Errorf("summary does not contain %s (found: %s)", e, summary)
+		}
+	}
+}
+
+func TestMavenProject_ToDOT(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.0.0</version>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.30</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	dot := project.ToDOT()
+	for _, expected := range []string{
+		"digraph dependencies {",
+		`"com.example:my-app:1.0.0";`,
+		`"com.example:my-app:1.0.0" -> "org.slf4j:slf4j-api:1.7.30" [label="compile"];`,
+		`"com.example:my-app:1.0.0" -> "junit:junit:4.12" [label="test"];`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("dot graph does not contain %s, found:\n%s", expected, dot)
+		}
+	}
+}
+
+func TestMavenProject_DependenciesText(t *testing.T) {
+	pomStr := `
+<project>
+    <properties>
+        <slf4j.version>1.7.30</slf4j.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.12</version>
+                <scope>test</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>javax.servlet</groupId>
+            <artifactId>servlet-api</artifactId>
+            <version>2.5</version>
+            <scope>provided</scope>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	expected := "javax.servlet:servlet-api:2.5 [provided]\n" +
+		"junit:junit:4.12 [test]\n" +
+		"org.slf4j:slf4j-api:1.7.30 [compile]\n"
+	if text := project.DependenciesText(); text != expected {
+		t.Errorf("dependencies text does not match (expected:\n%s\nfound:\n%s)", expected, text)
+	}
+}
+
+func TestMavenProject_Summary_CIFriendlyVersion(t *testing.T) {
+	pomStr := `
+<project>
+    <groupId>${project.parent.groupId}</groupId>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>2.0.0</version>
+    </parent>
+    <artifactId>my-app</artifactId>
+    <version>${revision}${changelist}</version>
+    <properties>
+        <revision>1.2.0</revision>
+        <changelist>-SNAPSHOT</changelist>
+    </properties>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	if summary := project.Summary(); !strings.HasPrefix(summary, "com.example:my-app:1.2.0-SNAPSHOT\n") {
+		t.Errorf("summary does not start with com.example:my-app:1.2.0-SNAPSHOT (found: %s)", summary)
+	}
+}
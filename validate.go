@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModelVersion is the POM model version supported by the parser
+const ModelVersion = "4.0.0"
+
+// NewerModelVersions list the model versions introduced after ModelVersion, whose additional
+// elements are preserved as RawExtensions
+var NewerModelVersions = []string{"4.1.0"}
+
+// Validator check projects for misconfigurations maven would reject
+type Validator struct {
+	// Lenient report a newer model version (see NewerModelVersions) as a warning rather than an error
+	Lenient bool
+}
+
+// Validate check the project for misconfigurations maven would reject, returning an error for each of
+// them, along with the warnings about the issues tolerated by the lenient mode
+func (v Validator) Validate(mp *MavenProject) (errs []error, warnings []string) {
+	if modelVersion := strings.TrimSpace(mp.ModelVersion); modelVersion != "" && modelVersion != ModelVersion {
+		if v.Lenient && contains(NewerModelVersions, modelVersion) {
+			warnings = append(warnings, fmt.Sprintf("modelVersion %s is newer than %s, unknown elements are preserved as is",
+				modelVersion, ModelVersion))
+		} else {
+			errs = append(errs, fmt.Errorf("unsupported modelVersion %s (expected: %s)", modelVersion, ModelVersion))
+		}
+	}
+
+	if version := mp.EffectiveVersion(); strings.Contains(version, "${") {
+		for _, key := range CIFriendlyProperties {
+			if strings.Contains(version, "${"+key+"}") {
+				errs = append(errs, fmt.Errorf("version %s uses undefined CI friendly property %s", version, key))
+			}
+		}
+	}
+
+	errs = append(errs, validateImportScope("dependencies", mp.Dependencies)...)
+	for _, profile := range mp.Profiles {
+		errs = append(errs, validateImportScope("profile "+profile.Id+" dependencies", profile.Dependencies)...)
+	}
+	return errs, warnings
+}
+
+// Validate check the project for misconfigurations maven would reject, returning an error for each of them
+func (mp *MavenProject) Validate() []error {
+	errs, _ := Validator{}.Validate(mp)
+	return errs
+}
+
+// validateImportScope flag the import scoped dependencies, only valid under dependencyManagement
+func validateImportScope(section string, deps []Dependency) []error {
+	var errs []error
+	for _, dep := range deps {
+		if dep.Scope == "import" {
+			errs = append(errs, fmt.Errorf("%s: dependency %s:%s has scope import which is only valid under dependencyManagement",
+				section, dep.GroupId, dep.ArtifactId))
+		}
+	}
+	return errs
+}
+
+// ClassifierlessTypes list the dependency types whose artifact can't have a classifier
+var ClassifierlessTypes = []string{"pom"}
+
+// ValidateTypeClassifier return an error for each dependency, managed or declared by a profile,
+// having a classifier on one of the ClassifierlessTypes. An explicit classifier overriding the one
+// implied by the type (e.g. test-jar with <classifier>jee8</classifier>) is valid.
+func (mp *MavenProject) ValidateTypeClassifier() []error {
+	var errs []error
+	validate := func(section string, deps []Dependency) {
+		for _, dep := range deps {
+			dep = mp.resolveDependency(dep)
+			if dep.Classifier == "" {
+				continue
+			}
+			if contains(ClassifierlessTypes, dep.EffectiveType()) {
+				errs = append(errs, fmt.Errorf("%s: dependency %s:%s has type %s which does not allow a classifier (found: %s)",
+					section, dep.GroupId, dep.ArtifactId, dep.EffectiveType(), dep.Classifier))
+			}
+		}
+	}
+
+	validate("dependencyManagement", mp.DependencyManagement.Dependencies)
+	validate("dependencies", mp.Dependencies)
+	for _, profile := range mp.Profiles {
+		validate("profile "+profile.Id+" dependencyManagement", profile.DependencyManagement.Dependencies)
+		validate("profile "+profile.Id+" dependencies", profile.Dependencies)
+	}
+	return errs
+}
@@ -0,0 +1,184 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestMavenProject_Validate_ImportScope(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.wildfly.bom</groupId>
+                <artifactId>wildfly-javaee8-with-tools</artifactId>
+                <version>18.0.0.Final</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-dependencies</artifactId>
+            <version>2.2.0.RELEASE</version>
+            <type>pom</type>
+            <scope>import</scope>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	errs := project.Validate()
+	if len(errs) != 1 {
+		t.Fatalf("expecting 1 error found %d", len(errs))
+	}
+	expected := "dependencies: dependency org.springframework.boot:spring-boot-dependencies has scope import which is only valid under dependencyManagement"
+	if errs[0].Error() != expected {
+		t.Errorf("error does not match (expected: %s, found: %s)", expected, errs[0])
+	}
+}
+
+func TestValidator_Validate_NewerModelVersion(t *testing.T) {
+	pomStr := `
+<project xmlns="http://maven.apache.org/POM/4.1.0">
+    <modelVersion>4.1.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>my-app</artifactId>
+    <version>1.0.0</version>
+    <subprojects>
+        <subproject>core</subproject>
+    </subprojects>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	project := pf.Project
+
+	if errs := project.Validate(); len(errs) != 1 || errs[0].Error() != "unsupported modelVersion 4.1.0 (expected: 4.0.0)" {
+		t.Errorf("expecting an unsupported modelVersion error, found %v", errs)
+	}
+	errs, warnings := Validator{Lenient: true}.Validate(project)
+	if len(errs) != 0 {
+		t.Errorf("expecting no error in lenient mode, found %v", errs)
+	}
+	if len(warnings) != 1 || warnings[0] != "modelVersion 4.1.0 is newer than 4.0.0, unknown elements are preserved as is" {
+		t.Errorf("expecting a newer modelVersion warning, found %v", warnings)
+	}
+
+	if len(project.RawExtensions) != 1 || project.RawExtensions[0].XMLName.Local != "subprojects" {
+		t.Fatalf("expecting subprojects to be preserved, found %v", project.RawExtensions)
+	}
+
+	var buf bytes.Buffer
+	if err := project.Write(&buf); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	if !strings.Contains(buf.String(), "<subprojects>\n        <subproject>core</subproject>\n    </subprojects>") {
+		t.Errorf("expecting subprojects to be written, found:\n%s", buf.String())
+	}
+	if strings.Count(buf.String(), "xmlns") != 1 {
+		t.Errorf("expecting namespace to be declared once, found:\n%s", buf.String())
+	}
+
+	reparsed, err := ParseReader(&buf)
+	if err != nil {
+		t.Fatalf("unable to parse written pom file. Reason: %s", err)
+	}
+	if len(reparsed.Project.RawExtensions) != 1 {
+		t.Fatalf("expecting subprojects to survive the round-trip, found %v", reparsed.Project.RawExtensions)
+	}
+	if subproject, _ := reparsed.Project.RawExtensions[0].Lookup("subproject"); subproject != "core" {
+		t.Errorf("subproject does not match (expected: core, found: %s)", subproject)
+	}
+}
+
+func TestMavenProject_ValidateTypeClassifier(t *testing.T) {
+	pomStr := `
+<project>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.example</groupId>
+                <artifactId>bom</artifactId>
+                <version>1.0</version>
+                <type>pom</type>
+                <classifier>sources</classifier>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>org.example</groupId>
+            <artifactId>core</artifactId>
+            <version>1.0</version>
+            <type>test-jar</type>
+            <classifier>tests</classifier>
+        </dependency>
+        <dependency>
+            <groupId>org.example</groupId>
+            <artifactId>api</artifactId>
+            <version>1.0</version>
+            <type>test-jar</type>
+            <classifier>javadoc</classifier>
+        </dependency>
+        <dependency>
+            <groupId>org.example</groupId>
+            <artifactId>native</artifactId>
+            <version>1.0</version>
+            <classifier>linux-x86_64</classifier>
+        </dependency>
+    </dependencies>
+</project>`
+
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(pomStr), &project); err != nil {
+		t.Fatalf("unable to unmarshal pom file. Reason: %s", err)
+	}
+
+	expected := []string{
+		"dependencyManagement: dependency org.example:bom has type pom which does not allow a classifier (found: sources)",
+	}
+	errs := project.ValidateTypeClassifier()
+	if len(errs) != len(expected) {
+		t.Fatalf("expecting %d errors found %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Errorf("error does not match (expected: %s, found: %s)", expected[i], err)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// well known qualifiers ordered from the oldest to the newest, unknown qualifiers come after
+var qualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var qualifierAliases = map[string]string{
+	"a":       "alpha",
+	"b":       "beta",
+	"m":       "milestone",
+	"cr":      "rc",
+	"ga":      "",
+	"final":   "",
+	"release": "",
+}
+
+// Represent a component of a version: either a number or a qualifier
+type versionItem struct {
+	number    int
+	qualifier string
+	isNumber  bool
+}
+
+// CompareVersions compare two maven versions, returning -1, 0 or 1 if a is respectively
+// lower, equal or greater than b. Numeric components are compared numerically and
+// qualifiers follow maven ordering (alpha < beta < milestone < rc < snapshot < release < sp).
+func CompareVersions(a, b string) int {
+	itemsA, itemsB := parseVersion(a), parseVersion(b)
+	for i := 0; i < len(itemsA) || i < len(itemsB); i++ {
+		var itemA, itemB versionItem
+		if i < len(itemsA) {
+			itemA = itemsA[i]
+		} else {
+			itemA.isNumber = itemsB[i].isNumber
+		}
+		if i < len(itemsB) {
+			itemB = itemsB[i]
+		} else {
+			itemB.isNumber = itemA.isNumber
+		}
+
+		if c := itemA.compare(itemB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func (i versionItem) compare(other versionItem) int {
+	switch {
+	case i.isNumber && other.isNumber:
+		switch {
+		case i.number < other.number:
+			return -1
+		case i.number > other.number:
+			return 1
+		}
+		return 0
+	case i.isNumber:
+		return 1
+	case other.isNumber:
+		return -1
+	}
+
+	rankA, rankB := qualifierRank(i.qualifier), qualifierRank(other.qualifier)
+	switch {
+	case rankA < rankB:
+		return -1
+	case rankA > rankB:
+		return 1
+	}
+	return strings.Compare(i.qualifier, other.qualifier)
+}
+
+func qualifierRank(qualifier string) int {
+	for i, q := range qualifiers {
+		if q == qualifier {
+			return i
+		}
+	}
+	return len(qualifiers)
+}
+
+// parseVersion split version into numbers and qualifiers, on separators and digit / letter transitions
+func parseVersion(version string) []versionItem {
+	var items []versionItem
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		token := current.String()
+		current.Reset()
+
+		if number, err := strconv.Atoi(token); err == nil {
+			items = append(items, versionItem{number: number, isNumber: true})
+			return
+		}
+		qualifier := strings.ToLower(token)
+		if alias, exist := qualifierAliases[qualifier]; exist {
+			qualifier = alias
+		}
+		items = append(items, versionItem{qualifier: qualifier})
+	}
+
+	isDigit := func(c rune) bool { return c >= '0' && c <= '9' }
+	var previous rune
+	for _, c := range strings.TrimSpace(version) {
+		if c == '.' || c == '-' || c == '_' {
+			flush()
+		} else {
+			if current.Len() > 0 && isDigit(c) != isDigit(previous) {
+				flush()
+			}
+			current.WriteRune(c)
+		}
+		previous = c
+	}
+	flush()
+
+	// trailing zero and release components do not affect ordering (1.0 == 1 == 1.0.0-final)
+	for len(items) > 0 {
+		last := items[len(items)-1]
+		if (last.isNumber && last.number == 0) || (!last.isNumber && last.qualifier == "") {
+			items = items[:len(items)-1]
+		} else {
+			break
+		}
+	}
+	return items
+}
+
+// Represent a maven version range such as [1.0,2.0) or a soft version requirement such as 1.0
+type VersionRange struct {
+	// The version recommended when the range is a soft requirement
+	Recommended  string
+	Restrictions []Restriction
+}
+
+// Represent a single interval of a version range. Empty bounds are unbounded.
+type Restriction struct {
+	Lower          string
+	LowerInclusive bool
+	Upper          string
+	UpperInclusive bool
+}
+
+// ParseVersionRange parse a maven version specification
+func ParseVersionRange(spec string) (VersionRange, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return VersionRange{}, fmt.Errorf("empty version range")
+	}
+	if !strings.ContainsAny(spec, "[(") {
+		if strings.ContainsAny(spec, "])") {
+			return VersionRange{}, fmt.Errorf("invalid version range %s", spec)
+		}
+		return VersionRange{Recommended: spec}, nil
+	}
+
+	var vr VersionRange
+	remaining := spec
+	for remaining != "" {
+		if remaining[0] != '[' && remaining[0] != '(' {
+			return VersionRange{}, fmt.Errorf("invalid version range %s", spec)
+		}
+		end := strings.IndexAny(remaining, "])")
+		if end == -1 {
+			return VersionRange{}, fmt.Errorf("unbounded version range %s", spec)
+		}
+
+		restriction, err := parseRestriction(remaining[:end+1])
+		if err != nil {
+			return VersionRange{}, fmt.Errorf("invalid version range %s, %v", spec, err)
+		}
+		vr.Restrictions = append(vr.Restrictions, restriction)
+
+		remaining = strings.TrimSpace(remaining[end+1:])
+		remaining = strings.TrimSpace(strings.TrimPrefix(remaining, ","))
+	}
+
+	return vr, nil
+}
+
+func parseRestriction(spec string) (Restriction, error) {
+	r := Restriction{
+		LowerInclusive: spec[0] == '[',
+		UpperInclusive: spec[len(spec)-1] == ']',
+	}
+	inner := spec[1 : len(spec)-1]
+
+	if !strings.Contains(inner, ",") {
+		// [1.0] means exactly 1.0
+		if !r.LowerInclusive || !r.UpperInclusive || strings.TrimSpace(inner) == "" {
+			return Restriction{}, fmt.Errorf("single version restriction must be inclusive")
+		}
+		r.Lower = strings.TrimSpace(inner)
+		r.Upper = r.Lower
+		return r, nil
+	}
+
+	bounds := strings.SplitN(inner, ",", 2)
+	r.Lower, r.Upper = strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+	if r.Lower != "" && r.Upper != "" && CompareVersions(r.Lower, r.Upper) > 0 {
+		return Restriction{}, fmt.Errorf("lower bound %s is greater than upper bound %s", r.Lower, r.Upper)
+	}
+	return r, nil
+}
+
+// IsRange return true if the specification contains explicit restrictions rather than a soft requirement
+func (vr VersionRange) IsRange() bool {
+	return len(vr.Restrictions) > 0
+}
+
+// Contains return true if version satisfies the range. A soft requirement is only satisfied
+// by the recommended version itself.
+func (vr VersionRange) Contains(version string) bool {
+	if !vr.IsRange() {
+		return CompareVersions(vr.Recommended, version) == 0
+	}
+
+	for _, r := range vr.Restrictions {
+		if r.Contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains return true if version is within the restriction bounds
+func (r Restriction) Contains(version string) bool {
+	if r.Lower != "" {
+		c := CompareVersions(version, r.Lower)
+		if c < 0 || (c == 0 && !r.LowerInclusive) {
+			return false
+		}
+	}
+	if r.Upper != "" {
+		c := CompareVersions(version, r.Upper)
+		if c > 0 || (c == 0 && !r.UpperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// intersect return the versions satisfying both restrictions, false if there are none
+func (r Restriction) intersect(other Restriction) (Restriction, bool) {
+	result := r
+	if other.Lower != "" {
+		c := 1
+		if r.Lower != "" {
+			c = CompareVersions(other.Lower, r.Lower)
+		}
+		if c > 0 {
+			result.Lower, result.LowerInclusive = other.Lower, other.LowerInclusive
+		} else if c == 0 {
+			result.LowerInclusive = r.LowerInclusive && other.LowerInclusive
+		}
+	}
+	if other.Upper != "" {
+		c := -1
+		if r.Upper != "" {
+			c = CompareVersions(other.Upper, r.Upper)
+		}
+		if c < 0 {
+			result.Upper, result.UpperInclusive = other.Upper, other.UpperInclusive
+		} else if c == 0 {
+			result.UpperInclusive = r.UpperInclusive && other.UpperInclusive
+		}
+	}
+
+	if result.Lower != "" && result.Upper != "" {
+		c := CompareVersions(result.Lower, result.Upper)
+		if c > 0 || (c == 0 && !(result.LowerInclusive && result.UpperInclusive)) {
+			return Restriction{}, false
+		}
+	}
+	return result, true
+}
+
+// IsSnapshot return true if version designate a development (SNAPSHOT) version
+func IsSnapshot(version string) bool {
+	return strings.HasSuffix(strings.ToUpper(strings.TrimSpace(version)), "SNAPSHOT")
+}
@@ -0,0 +1,85 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1", 0},
+		{"1.0.0", "1.0-final", 0},
+		{"1.2", "1.10", -1},
+		{"2.0", "1.9.9", 1},
+		{"1.0-SNAPSHOT", "1.0", -1},
+		{"1.0-alpha-1", "1.0-beta-1", -1},
+		{"1.0-rc1", "1.0-SNAPSHOT", -1},
+		{"1.0-RC1", "1.0-rc2", -1},
+		{"1.0", "1.0-sp1", -1},
+		{"1.0.1", "1.0-rc1", 1},
+	}
+
+	for _, test := range tests {
+		if c := CompareVersions(test.a, test.b); c != test.expected {
+			t.Errorf("comparison of %s and %s does not match (expected: %d, found: %d)", test.a, test.b, test.expected, c)
+		}
+	}
+}
+
+func TestParseVersionRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		version  string
+		expected bool
+	}{
+		{"1.0", "1.0", true},
+		{"1.0", "1.1", false},
+		{"[1.0]", "1.0", true},
+		{"[1,2)", "1.5", true},
+		{"[1,2)", "2.0", false},
+		{"[1,2]", "2.0", true},
+		{"(,1.0]", "0.9", true},
+		{"(1.0,)", "1.0", false},
+		{"(,1.0],[1.2,)", "1.1", false},
+		{"(,1.0],[1.2,)", "1.3", true},
+	}
+
+	for _, test := range tests {
+		vr, err := ParseVersionRange(test.spec)
+		if err != nil {
+			t.Errorf("unable to parse version range %s. Reason: %s", test.spec, err)
+			continue
+		}
+		if vr.Contains(test.version) != test.expected {
+			t.Errorf("%s contains %s does not match (expected: %t)", test.spec, test.version, test.expected)
+		}
+	}
+
+	for _, spec := range []string{"", "[1.0", "(1.0)", "[2.0,1.0]", "1.0]"} {
+		if _, err := ParseVersionRange(spec); err == nil {
+			t.Errorf("expecting an error when parsing %s", spec)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is the namespace bound to the reserved xml prefix (e.g. xml:space)
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Write serialize the project as an indented pom.xml document.
+//
+// Go strings cannot tell an absent element from an empty one, so blank scalars and
+// zero-valued sections are never written: parsing <version></version> and writing it back
+// drop the element. The few elements where an explicit empty value is meaningful are
+// modelled with pointer fields (e.g. Parent.RelativePath): nil is absent and a pointer to
+// an empty string is written as an empty element.
+//
+// Explicitly declared empty containers are kept. ParseFile and ParseReader decode lists such as
+// <modules/>, <exclusions/> or <profiles/> into an empty but non nil slice, written as an empty
+// element, while a nil slice is absent. Free-form content is written as parsed, empty elements included: plugin
+// configuration (an empty <configuration/> too), the properties values and RawExtensions. An
+// empty <properties/> is dropped.
+//
+// Empty elements are always written as <name></name>. Since the written document holds no
+// element dropped by the policy above, parsing and writing it again produce the same output.
+func (mp *MavenProject) Write(w io.Writer) error {
+	return writeDocument(w, "", mp)
+}
+
+// WriteCanonical serialize the project of the parsed file like MavenProject.Write, preceded by
+// the preamble (license header, processing instructions) read before the project element
+func (pf *ParsedFile) WriteCanonical(w io.Writer) error {
+	return writeDocument(w, pf.Preamble, pf.Project)
+}
+
+// writeDocument write the XML declaration, the preamble if any and the indented project
+func writeDocument(w io.Writer, preamble string, mp *MavenProject) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if preamble != "" {
+		if _, err := io.WriteString(w, preamble+"\n"); err != nil {
+			return err
+		}
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "    ")
+	if err := encoder.Encode(mp); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// MarshalXML encode the project following the empty elements policy described by Write
+func (mp MavenProject) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Space: mp.XMLName.Space, Local: "project"}, Attr: mp.attrs}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := encodeFields(e, reflect.ValueOf(mp)); err != nil {
+		return err
+	}
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// MarshalXML encode each property as a child element, sorted by key
+func (p Properties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := e.EncodeElement(p[key], xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML encode the description text verbatim, along with its xml:space attribute if any
+func (d Description) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.Space != "" {
+		start.Attr = []xml.Attr{{Name: xml.Name{Space: xmlNamespace, Local: "space"}, Value: d.Space}}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(d.Value)); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML encode the configuration element, dropping the POM namespace and indentation captured while parsing
+func (c Config) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if strings.HasPrefix(start.Name.Space, pomNamespacePrefix) {
+		start.Name.Space = ""
+	}
+	start.Attr = withoutNamespaceDeclarations(c.Attrs)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if len(c.Children) == 0 || strings.TrimSpace(c.Value) != "" {
+		if err := e.EncodeToken(xml.CharData(c.Value)); err != nil {
+			return err
+		}
+	}
+	for _, child := range c.Children {
+		if err := child.MarshalXML(e, xml.StartElement{Name: child.XMLName}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// withoutNamespaceDeclarations return attrs without the xmlns declarations captured while parsing,
+// the encoder declaring the namespaces of the names it writes itself
+func withoutNamespaceDeclarations(attrs []xml.Attr) []xml.Attr {
+	var kept []xml.Attr
+	for _, attr := range attrs {
+		if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+			kept = append(kept, attr)
+		}
+	}
+	return kept
+}
+
+// rootAttributes return the attributes of the project element to write back, without the
+// declarations of the project namespace which the encoder writes itself. Other namespaced
+// attributes (e.g. xsi:schemaLocation) keep the prefix declared in the parsed document.
+func rootAttributes(start xml.StartElement) []xml.Attr {
+	prefixes := map[string]string{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" && attr.Value != start.Name.Space {
+			prefixes[attr.Value] = attr.Name.Local
+		}
+	}
+
+	var kept []xml.Attr
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+		case attr.Name.Space == "xmlns":
+			if prefixes[attr.Value] == attr.Name.Local {
+				kept = append(kept, xml.Attr{Name: xml.Name{Local: "xmlns:" + attr.Name.Local}, Value: attr.Value})
+			}
+		case prefixes[attr.Name.Space] != "":
+			kept = append(kept, xml.Attr{Name: xml.Name{Local: prefixes[attr.Name.Space] + ":" + attr.Name.Local},
+				Value: attr.Value})
+		default:
+			kept = append(kept, attr)
+		}
+	}
+	return kept
+}
+
+// encodeFields encode the non empty exported fields of the struct v using their xml tag
+func encodeFields(e *xml.Encoder, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+		if field.PkgPath != "" || field.Type == xmlNameType || isEmptyValue(value) {
+			continue
+		}
+
+		if field.Tag.Get("xml") == ",any" {
+			// preserved unknown elements keep their own name
+			for j := 0; j < value.Len(); j++ {
+				raw := value.Index(j).Interface().(Config)
+				if err := raw.MarshalXML(e, xml.StartElement{Name: raw.XMLName}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("xml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		// a>b paths wrap each value in the parent elements
+		path := strings.Split(name, ">")
+		parents, leaf := path[:len(path)-1], path[len(path)-1]
+		for _, parent := range parents {
+			if err := e.EncodeToken(xml.StartElement{Name: xml.Name{Local: parent}}); err != nil {
+				return err
+			}
+		}
+
+		if value.Kind() == reflect.Slice {
+			for j := 0; j < value.Len(); j++ {
+				if err := encodeValue(e, leaf, value.Index(j)); err != nil {
+					return err
+				}
+			}
+		} else if err := encodeValue(e, leaf, value); err != nil {
+			return err
+		}
+
+		for j := len(parents) - 1; j >= 0; j-- {
+			if err := e.EncodeToken(xml.EndElement{Name: xml.Name{Local: parents[j]}}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeValue encode v as an element with given name
+func encodeValue(e *xml.Encoder, name string, v reflect.Value) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if _, ok := v.Interface().(xml.Marshaler); ok || v.Kind() != reflect.Struct {
+		return e.EncodeElement(v.Interface(), start)
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := encodeFields(e, v); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// isEmptyValue return true if v should not be written (blank, false, nil or without any element).
+// An empty but non nil slice is an explicitly declared empty container and a named Config an
+// element read from the document, both are written.
+func isEmptyValue(v reflect.Value) bool {
+	if config, ok := v.Interface().(Config); ok && config.XMLName.Local != "" {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.IsNil()
+	case reflect.Map:
+		return v.Len() == 0
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" && v.Type().Field(i).Type != xmlNameType && !isEmptyValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,342 @@
+// MIT License
+//
+// Copyright (c) 2019 Aloïs Micard
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+//	copies or substantial portions of the Software.
+//
+//	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mvnparser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMavenProject_Write(t *testing.T) {
+	pomStr := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+        <relativePath/>
+    </parent>
+    <artifactId>my-app</artifactId>
+    <properties>
+        <slf4j.version>1.7.22</slf4j.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>${slf4j.version}</version>
+            <classifier></classifier>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <release>11</release>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := pf.Project.Write(&buf); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	output := buf.String()
+
+	// absent or blank values are not emitted
+	for _, absent := range []string{"<groupId></groupId>", "<version></version>", "<classifier>", "<optional>",
+		"<scm>", "<organization>", "<distributionManagement>", "<dependencyManagement>", "<profiles>", "<packaging>",
+		"<resources>", "<pluginManagement>"} {
+		if strings.Contains(output, absent) {
+			t.Errorf("expecting %s not to be written, found:\n%s", absent, output)
+		}
+	}
+	// explicit empty relativePath is preserved
+	for _, present := range []string{
+		`<project xmlns="http://maven.apache.org/POM/4.0.0">`,
+		"<relativePath></relativePath>",
+		"<slf4j.version>1.7.22</slf4j.version>",
+		"<version>${slf4j.version}</version>",
+		"<release>11</release>",
+	} {
+		if !strings.Contains(output, present) {
+			t.Errorf("expecting %s to be written, found:\n%s", present, output)
+		}
+	}
+	if strings.Count(output, "xmlns") != 1 {
+		t.Errorf("expecting namespace to be declared once, found:\n%s", output)
+	}
+
+	reparsed, err := ParseReader(&buf)
+	if err != nil {
+		t.Fatalf("unable to parse written pom file. Reason: %s", err)
+	}
+	project := reparsed.Project
+	if project.ArtifactId != "my-app" || project.Parent.Version != "1.0.0" {
+		t.Errorf("coordinates does not match (expected: my-app / parent 1.0.0, found: %s / parent %s)",
+			project.ArtifactId, project.Parent.Version)
+	}
+	if project.Parent.RelativePath == nil || *project.Parent.RelativePath != "" {
+		t.Errorf("expecting an explicitly empty relativePath, found %v", project.Parent.RelativePath)
+	}
+
+	project.Parent.RelativePath = nil
+	buf.Reset()
+	if err := project.Write(&buf); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	if strings.Contains(buf.String(), "relativePath") {
+		t.Errorf("expecting absent relativePath not to be written, found:\n%s", buf.String())
+	}
+}
+
+func TestParsedFile_WriteCanonical(t *testing.T) {
+	pomStr := `<?xml version="1.0" encoding="UTF-8"?>
+<!--
+  Licensed under the Apache License, Version 2.0
+-->
+<?generated-by tool="archetype"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>org.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1.0</version>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "<!--\n  Licensed under the Apache License, Version 2.0\n-->\n<?generated-by tool=\"archetype\"?>"
+	if pf.Preamble != expected {
+		t.Errorf("preamble does not match (expected: %s, found: %s)", expected, pf.Preamble)
+	}
+
+	var buf bytes.Buffer
+	if err := pf.WriteCanonical(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != pomStr+"\n" {
+		t.Errorf("written pom does not match (expected: %s, found: %s)", pomStr, buf.String())
+	}
+
+	reparsed, err := ParseReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reparsed.Preamble != pf.Preamble {
+		t.Errorf("preamble does not match (expected: %s, found: %s)", pf.Preamble, reparsed.Preamble)
+	}
+}
+
+func TestMavenProject_Write_Idempotent(t *testing.T) {
+	pomStr := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+        <relativePath/>
+    </parent>
+    <artifactId>my-app</artifactId>
+    <packaging/>
+    <name></name>
+    <description/>
+    <url>  </url>
+    <scm/>
+    <modules/>
+    <properties>
+        <sha1/>
+        <revision>1.0.0</revision>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <optional/>
+            <exclusions/>
+        </dependency>
+    </dependencies>
+    <build>
+        <finalName/>
+        <resources/>
+        <plugins>
+            <plugin>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <configuration>
+                    <skip/>
+                    <argLine>
+                    </argLine>
+                </configuration>
+                <executions/>
+            </plugin>
+            <plugin>
+                <artifactId>maven-jar-plugin</artifactId>
+                <configuration/>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles/>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	var first bytes.Buffer
+	if err := pf.Project.Write(&first); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+
+	reparsed, err := ParseReader(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to parse written pom file. Reason: %s", err)
+	}
+	var second bytes.Buffer
+	if err := reparsed.Project.Write(&second); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+
+	if first.String() != second.String() {
+		t.Errorf("second write does not match the first (expected:\n%s\nfound:\n%s)", first.String(), second.String())
+	}
+
+	output := first.String()
+	for _, absent := range []string{"<packaging>", "<name>", "<url>", "<scm>", "<optional>", "<finalName>"} {
+		if strings.Contains(output, absent) {
+			t.Errorf("expecting %s not to be written, found:\n%s", absent, output)
+		}
+	}
+	// explicitly declared empty containers are kept
+	for _, present := range []string{"<relativePath></relativePath>", "<sha1></sha1>", "<skip></skip>",
+		"<description></description>", "<modules></modules>", "<exclusions></exclusions>",
+		"<resources></resources>", "<executions></executions>", "<profiles></profiles>",
+		"<configuration></configuration>", `<project xmlns="http://maven.apache.org/POM/4.0.0" ` +
+			`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
+			`xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">`} {
+		if !strings.Contains(output, present) {
+			t.Errorf("expecting %s to be written, found:\n%s", present, output)
+		}
+	}
+}
+
+func TestMavenProject_Write_PreservedDescription(t *testing.T) {
+	description := "Usage:\n\n    mvn verify\n    then deploy  "
+	pomStr := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <artifactId>my-app</artifactId>
+    <description xml:space="preserve">` + description + `</description>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	if text := pf.Project.Description.Text(); text != description {
+		t.Errorf("description does not match (expected: %q, found: %q)", description, text)
+	}
+
+	var buf bytes.Buffer
+	if err := pf.Project.Write(&buf); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	expected := `<description xml:space="preserve">` + description + `</description>`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("expecting %s to be written, found:\n%s", expected, buf.String())
+	}
+
+	reparsed, err := ParseReader(&buf)
+	if err != nil {
+		t.Fatalf("unable to parse written pom file. Reason: %s", err)
+	}
+	if text := reparsed.Project.Description.Text(); text != description {
+		t.Errorf("description does not match (expected: %q, found: %q)", description, text)
+	}
+
+	// without the attribute the whitespace is not significant
+	reparsed.Project.Description.Space = ""
+	if text := reparsed.Project.Description.Text(); text != "Usage: mvn verify then deploy" {
+		t.Errorf("description does not match (expected: Usage: mvn verify then deploy, found: %q)", text)
+	}
+}
+
+func TestMavenProject_Write_NamespacedConfiguration(t *testing.T) {
+	pomStr := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <artifactId>my-app</artifactId>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-antrun-plugin</artifactId>
+                <configuration>
+                    <target xmlns:if="ant:if" name="copy">
+                        <echo if:set="verbose" message="copying"></echo>
+                    </target>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+	pf, err := ParseReader(strings.NewReader(pomStr))
+	if err != nil {
+		t.Fatalf("unable to parse pom file. Reason: %s", err)
+	}
+	var first bytes.Buffer
+	if err := pf.Project.Write(&first); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	if strings.Contains(first.String(), "_xmlns") {
+		t.Errorf("expecting namespace declarations not to be mangled, found:\n%s", first.String())
+	}
+
+	reparsed, err := ParseReader(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to parse written pom file. Reason: %s", err)
+	}
+	var second bytes.Buffer
+	if err := reparsed.Project.Write(&second); err != nil {
+		t.Fatalf("unable to write pom file. Reason: %s", err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("second write does not match the first (expected:\n%s\nfound:\n%s)", first.String(), second.String())
+	}
+
+	echo, exist := reparsed.Project.Build.Plugins[0].Configuration.Get("target.echo")
+	if !exist || echo.attr("set") != "verbose" || echo.attr("message") != "copying" {
+		t.Errorf("expecting the echo attributes to be preserved, found %v", echo.Attrs)
+	}
+}